@@ -93,6 +93,28 @@ func TestArrayStringGet(t *testing.T) {
 	}
 }
 
+func TestArrayStringNulls(t *testing.T) {
+	require := require.New(t)
+	b := NewStringArrayBuilder()
+	require.NoError(b.Append("a"), "append")
+	require.NoError(b.AppendNull(), "append null")
+	require.NoError(b.AppendNull(), "append second null")
+	for i := 0; i < bufferSize; i++ {
+		require.NoErrorf(b.Append("b"), "append %d", i)
+	}
+	require.NoError(b.AppendNull(), "append null after flush")
+	arr, err := b.Finish()
+	require.NoError(err, "finish")
+
+	require.Equal(bufferSize+4, arr.Length(), "length")
+	v, err := arr.StringAt(0)
+	require.NoError(err, "StringAt(0)")
+	require.Equal("a", v, "value")
+	require.True(arr.IsNull(1), "null 1")
+	require.True(arr.IsNull(2), "null 2")
+	require.True(arr.IsNull(bufferSize+3), "last null")
+}
+
 func TestArrayTimeGet(t *testing.T) {
 	require := require.New(t)
 	b := NewTimestampArrayBuilder()
@@ -118,3 +140,80 @@ func TestArrayTimeGet(t *testing.T) {
 		require.True(v.Equal(tval(i)), "time at %d", i)
 	}
 }
+
+func buildIntArray(require *require.Assertions, values ...int64) *Array {
+	b := NewInteger64ArrayBuilder()
+	require.NotNil(b, "create")
+	for _, v := range values {
+		err := b.Append(v)
+		require.NoErrorf(err, "append %d", v)
+	}
+
+	arr, err := b.Finish()
+	require.NoError(err, "finish")
+	return arr
+}
+
+func TestArraySlice(t *testing.T) {
+	require := require.New(t)
+	arr := buildIntArray(require, 0, 1, 2, 3, 4, 5)
+
+	s, err := arr.Slice(2, 3)
+	require.NoError(err, "slice")
+	require.Equal(3, s.Length(), "length")
+	v, err := s.Int64At(0)
+	require.NoError(err, "int at 0")
+	require.Equal(int64(2), v, "first value")
+
+	s, err = arr.Slice(4, -1)
+	require.NoError(err, "slice to end")
+	require.Equal(2, s.Length(), "length to end")
+
+	s, err = arr.Slice(6, -1)
+	require.NoError(err, "empty slice at end")
+	require.Equal(0, s.Length(), "empty length")
+
+	_, err = arr.Slice(7, -1)
+	require.Error(err, "offset past end")
+	_, err = arr.Slice(-1, 2)
+	require.Error(err, "negative offset")
+	_, err = arr.Slice(2, -2)
+	require.Error(err, "negative length")
+	_, err = arr.Slice(4, 3)
+	require.Error(err, "length past end")
+}
+
+func TestArrayConcatenate(t *testing.T) {
+	require := require.New(t)
+	a1 := buildIntArray(require, 1, 2)
+	a2 := buildIntArray(require, 3)
+
+	arr, err := Concatenate(a1, a2)
+	require.NoError(err, "concatenate")
+
+	expected := buildIntArray(require, 1, 2, 3)
+	ok, err := arr.Equal(expected)
+	require.NoError(err, "equal")
+	require.True(ok, "concatenated values")
+}
+
+func TestArrayApproxEqual(t *testing.T) {
+	require := require.New(t)
+	b1, b2 := NewFloat64ArrayBuilder(), NewFloat64ArrayBuilder()
+	for i := 0; i < 10; i++ {
+		require.NoError(b1.Append(float64(i)), "append b1")
+		require.NoError(b2.Append(float64(i)+1e-9), "append b2")
+	}
+	a1, err := b1.Finish()
+	require.NoError(err, "finish b1")
+	a2, err := b2.Finish()
+	require.NoError(err, "finish b2")
+
+	ok, err := a1.Equal(a2)
+	require.NoError(err, "equal")
+	require.False(ok, "equal")
+
+	ok, err = a1.ApproxEqual(a2, 1e-6)
+	require.NoError(err, "approx equal")
+	require.True(ok, "approx equal")
+}
@@ -0,0 +1,128 @@
+package carrow
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+/*
+#include "carrow.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// RecordBatch is a collection of equal length arrays matching a schema
+type RecordBatch struct {
+	ptr unsafe.Pointer
+}
+
+// NewRecordBatchFromPtr creates a new record batch from underlying C pointer
+// You probably shouldn't use this function
+func NewRecordBatchFromPtr(ptr unsafe.Pointer) *RecordBatch {
+	return &RecordBatch{ptr}
+}
+
+// NumRows returns the number of rows
+func (b *RecordBatch) NumRows() int {
+	return int(C.record_batch_num_rows(b.ptr))
+}
+
+// NumCols returns the number of columns
+func (b *RecordBatch) NumCols() int {
+	return int(C.record_batch_num_cols(b.ptr))
+}
+
+// Schema returns the record batch Schema
+func (b *RecordBatch) Schema() *Schema {
+	ptr := C.record_batch_schema(b.ptr)
+	if ptr == nil {
+		return nil
+	}
+
+	return &Schema{ptr}
+}
+
+// Column returns the nth column
+func (b *RecordBatch) Column(i int) (*Array, error) {
+	ptr := C.record_batch_column(b.ptr, C.int(i))
+	if ptr == nil {
+		return nil, fmt.Errorf("can't find column %d", i)
+	}
+
+	return &Array{ptr}, nil
+}
+
+// Field returns the nth field
+func (b *RecordBatch) Field(i int) (*Field, error) {
+	ptr := C.record_batch_field(b.ptr, C.int(i))
+	if ptr == nil {
+		return nil, fmt.Errorf("can't find field %d", i)
+	}
+
+	return &Field{ptr}, nil
+}
+
+// Slice returns a 0 copy slice of b
+// If length is -1 will return until end of batch
+func (b *RecordBatch) Slice(offset int, length int) (*RecordBatch, error) {
+	length, err := sliceLength(offset, length, b.NumRows())
+	if err != nil {
+		return nil, err
+	}
+
+	ptr := C.record_batch_slice(b.ptr, C.int64_t(offset), C.int64_t(length))
+	return &RecordBatch{ptr}, nil
+}
+
+// Ptr returns the underlying C++ pointer
+func (b *RecordBatch) Ptr() unsafe.Pointer {
+	return b.ptr
+}
+
+// Batches returns t as record batches with at most maxChunkSize rows each
+// The batches are 0 copy, if maxChunkSize <= 0 batches follow the table
+// chunk layout
+func (t *Table) Batches(maxChunkSize int) ([]*RecordBatch, error) {
+	r := C.table_batch_reader(t.ptr, C.int64_t(maxChunkSize))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+	rdr := r.ptr
+	defer C.batch_reader_free(rdr)
+
+	var batches []*RecordBatch
+	for {
+		r := C.batch_reader_next(rdr)
+		if err := errFromResult(r); err != nil {
+			return nil, err
+		}
+
+		if r.ptr == nil {
+			break
+		}
+		batches = append(batches, &RecordBatch{r.ptr})
+	}
+
+	return batches, nil
+}
+
+// NewTableFromBatches creates a new Table from record batches
+// All batches must have the same schema
+func NewTableFromBatches(batches []*RecordBatch) (*Table, error) {
+	if len(batches) == 0 {
+		return nil, fmt.Errorf("no record batches")
+	}
+
+	ptrs := make([]unsafe.Pointer, 0, len(batches))
+	for _, b := range batches {
+		ptrs = append(ptrs, b.ptr)
+	}
+
+	bptr := (unsafe.Pointer)(&ptrs[0])
+	r := C.table_from_batches(bptr, C.size_t(len(ptrs)))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
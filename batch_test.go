@@ -0,0 +1,50 @@
+package carrow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatches(t *testing.T) {
+	require := require.New(t)
+	nrows, chunkSize := 117, 50
+	table := buildTable(require, nrows)
+
+	batches, err := table.Batches(chunkSize)
+	require.NoError(err, "batches")
+	require.Len(batches, 3, "number of batches")
+	require.Equal(chunkSize, batches[0].NumRows(), "first batch rows")
+	require.Equal(nrows%chunkSize, batches[2].NumRows(), "last batch rows")
+	require.Equal(2, batches[0].NumCols(), "batch columns")
+	require.NotNil(batches[0].Schema(), "batch schema")
+
+	fld, err := batches[1].Field(0)
+	require.NoError(err, "Field(0)")
+	require.Equal(intColName, fld.Name(), "field name")
+
+	arr, err := batches[1].Column(0)
+	require.NoError(err, "Column(0)")
+	v, err := arr.Int64At(0)
+	require.NoError(err, "Int64At(0)")
+	require.Equal(int64(chunkSize), v, "first value of second batch")
+
+	s, err := batches[0].Slice(10, 5)
+	require.NoError(err, "slice")
+	require.Equal(5, s.NumRows(), "slice rows")
+	s, err = batches[0].Slice(10, -1)
+	require.NoError(err, "slice to end")
+	require.Equal(batches[0].NumRows()-10, s.NumRows(), "slice to end rows")
+	_, err = batches[0].Slice(-1, 5)
+	require.Error(err, "negative offset")
+	_, err = batches[0].Slice(10, batches[0].NumRows())
+	require.Error(err, "length out of range")
+
+	out, err := NewTableFromBatches(batches)
+	require.NoError(err, "table from batches")
+	require.Equal(nrows, out.NumRows(), "rows")
+
+	ok, err := out.Equal(table)
+	require.NoError(err, "equal")
+	require.True(ok, "round trip")
+}
@@ -1,10 +1,21 @@
 #include <arrow/api.h>
+#include <arrow/compute/api.h>
 #include <arrow/io/api.h>
 #include <arrow/ipc/api.h>
+#include <arrow/pretty_print.h>
 #include <plasma/client.h>
 
+#include <cctype>
+#include <climits>
+#include <ctime>
+#include <cmath>
+#include <functional>
+#include <algorithm>
 #include <iostream>
+#include <limits>
 #include <sstream>
+#include <unordered_map>
+#include <unordered_set>
 #include <vector>
 
 #include "carrow.h"
@@ -18,6 +29,9 @@ const int FLOAT64_DTYPE = arrow::Type::DOUBLE;
 const int INTEGER64_DTYPE = arrow::Type::INT64;
 const int STRING_DTYPE = arrow::Type::STRING;
 const int TIMESTAMP_DTYPE = arrow::Type::TIMESTAMP;
+const int DICTIONARY_DTYPE = arrow::Type::DICTIONARY;
+const int LIST_DTYPE = arrow::Type::LIST;
+const int STRUCT_DTYPE = arrow::Type::STRUCT;
 
 /*
 static void debug_mark(std::string msg = "HERE") {
@@ -31,7 +45,14 @@ static void debug_mark(std::string msg = "HERE") {
 #define CARROW_RETURN_IF_ERROR(status)                                         \
   do {                                                                         \
     if (!status.ok()) {                                                        \
-      return result_t{status.message().c_str(), nullptr};                      \
+      return result_t{strdup(status.message().c_str()), nullptr};              \
+    }                                                                          \
+  } while (false)
+
+#define CARROW_RETURN_SCALAR_IF_ERROR(status)                                  \
+  do {                                                                         \
+    if (!status.ok()) {                                                        \
+      return scalar_t{strdup(status.message().c_str()), -1};                   \
     }                                                                          \
   } while (false)
 
@@ -73,10 +94,23 @@ struct Array {
   std::shared_ptr<arrow::Array> ptr;
 };
 
+struct ChunkedArray {
+  std::shared_ptr<arrow::ChunkedArray> ptr;
+};
+
 struct Table {
   std::shared_ptr<arrow::Table> ptr;
 };
 
+struct RecordBatch {
+  std::shared_ptr<arrow::RecordBatch> ptr;
+};
+
+struct BatchReader {
+  std::shared_ptr<arrow::Table> table; // reader references the table
+  std::shared_ptr<arrow::TableBatchReader> reader;
+};
+
 void *field_new(char *name, int dtype) {
   auto dt = data_type(dtype);
   return new arrow::Field(name, dt);
@@ -143,6 +177,19 @@ result_t schema_meta(void *vp) {
   return res;
 }
 
+result_t schema_to_string(void *vp) {
+  auto schema = (Schema *)vp;
+  if (schema == nullptr) {
+    return result_t{strdup("null schema"), nullptr};
+  }
+
+  std::ostringstream oss;
+  auto status =
+      arrow::PrettyPrint(*schema->ptr, arrow::PrettyPrintOptions(0), &oss);
+  CARROW_RETURN_IF_ERROR(status);
+  return result_t{nullptr, strdup(oss.str().c_str())};
+}
+
 void schema_free(void *vp) {
   if (vp == nullptr) {
     return;
@@ -151,6 +198,38 @@ void schema_free(void *vp) {
   delete schema;
 }
 
+// Go works in nanoseconds (time.Time), timestamps read from CSV, parquet ...
+// can be in other units. All timestamp values passed to Go go through
+// timestamp_nanos, all timestamp arrays compared with Go values through
+// timestamps_as_nanos.
+int64_t timestamp_nanos(const arrow::DataType &type, int64_t value) {
+  switch (((const arrow::TimestampType &)type).unit()) {
+  case arrow::TimeUnit::SECOND:
+    return value * 1000000000LL;
+  case arrow::TimeUnit::MILLI:
+    return value * 1000000LL;
+  case arrow::TimeUnit::MICRO:
+    return value * 1000LL;
+  case arrow::TimeUnit::NANO:
+    break;
+  }
+  return value;
+}
+
+// Cast timestamp arrays to nanoseconds, other arrays are returned as is
+arrow::Status timestamps_as_nanos(const std::shared_ptr<arrow::Array> &values,
+                                  std::shared_ptr<arrow::Array> *out) {
+  auto type = data_type(TIMESTAMP_DTYPE);
+  if ((values->type_id() != TIMESTAMP_DTYPE) || values->type()->Equals(type)) {
+    *out = values;
+    return arrow::Status::OK();
+  }
+
+  arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+  return arrow::compute::Cast(&ctx, *values, type,
+                              arrow::compute::CastOptions::Safe(), out);
+}
+
 result_t array_builder_new(int dtype) {
   result_t res = {nullptr, nullptr};
   switch (dtype) {
@@ -250,6 +329,13 @@ result_t array_builder_append_timestamps(void *vp, long *values,
   return result_t{nullptr, nullptr};
 }
 
+result_t array_builder_append_null(void *vp) {
+  auto builder = (arrow::ArrayBuilder *)vp;
+  auto status = builder->AppendNull();
+  CARROW_RETURN_IF_ERROR(status);
+  return result_t{nullptr, nullptr};
+}
+
 result_t array_builder_finish(void *vp) {
   auto builder = (arrow::ArrayBuilder *)vp;
   std::shared_ptr<arrow::Array> array;
@@ -348,7 +434,7 @@ int64_t array_timestamp_at(void *vp, long long i) {
   }
 
   auto arr = (arrow::TimestampArray *)(wrapper->ptr.get());
-  return arr->Value(i);
+  return timestamp_nanos(*arr->type(), arr->Value(i));
 }
 
 void array_free(void *vp) {
@@ -359,6 +445,204 @@ void array_free(void *vp) {
   delete (Array *)vp;
 }
 
+int array_is_null(void *vp, long long i) {
+  auto wrapper = (Array *)vp;
+  if (wrapper == nullptr) {
+    return -1;
+  }
+
+  return wrapper->ptr->IsNull(i) ? 1 : 0;
+}
+
+result_t array_to_string(void *vp) {
+  auto wrapper = (Array *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::ostringstream oss;
+  auto status = arrow::PrettyPrint(*wrapper->ptr, 0, &oss);
+  CARROW_RETURN_IF_ERROR(status);
+  return result_t{nullptr, strdup(oss.str().c_str())};
+}
+
+result_t array_slice(void *vp, int64_t offset, int64_t length) {
+  auto wrapper = (Array *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  // Arrow checks bounds only in debug builds
+  if ((offset < 0) || (length < 0) ||
+      (offset + length > wrapper->ptr->length())) {
+    return result_t{strdup("slice out of range"), nullptr};
+  }
+
+  auto array = new Array;
+  array->ptr = wrapper->ptr->Slice(offset, length);
+  return result_t{nullptr, array};
+}
+
+result_t array_concatenate(void *vp, size_t count) {
+  auto wrappers = (Array **)vp;
+  arrow::ArrayVector arrays;
+  for (size_t i = 0; i < count; i++) {
+    arrays.push_back(wrappers[i]->ptr);
+  }
+
+  std::shared_ptr<arrow::Array> out;
+  auto status = arrow::Concatenate(arrays, arrow::default_memory_pool(), &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto array = new Array;
+  array->ptr = out;
+  return result_t{nullptr, array};
+}
+
+result_t array_equal(void *vp, void *op) {
+  auto wrapper = (Array *)vp;
+  auto other = (Array *)op;
+  if ((wrapper == nullptr) || (other == nullptr)) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  result_t res = {nullptr, nullptr};
+  res.i = wrapper->ptr->Equals(other->ptr) ? 1 : 0;
+  return res;
+}
+
+result_t array_approx_equal(void *vp, void *op, double epsilon) {
+  auto wrapper = (Array *)vp;
+  auto other = (Array *)op;
+  if ((wrapper == nullptr) || (other == nullptr)) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto opts = arrow::EqualOptions::Defaults().atol(epsilon);
+  result_t res = {nullptr, nullptr};
+  res.i = wrapper->ptr->ApproxEquals(*other->ptr, opts) ? 1 : 0;
+  return res;
+}
+
+int chunked_array_dtype(void *vp) {
+  if (vp == nullptr) {
+    return -1;
+  }
+
+  auto wrapper = (ChunkedArray *)vp;
+  return wrapper->ptr->type()->id();
+}
+
+int64_t chunked_array_length(void *vp) {
+  if (vp == nullptr) {
+    return -1;
+  }
+
+  auto wrapper = (ChunkedArray *)vp;
+  return wrapper->ptr->length();
+}
+
+int chunked_array_num_chunks(void *vp) {
+  if (vp == nullptr) {
+    return -1;
+  }
+
+  auto wrapper = (ChunkedArray *)vp;
+  return wrapper->ptr->num_chunks();
+}
+
+void *chunked_array_chunk(void *vp, int i) {
+  auto wrapper = (ChunkedArray *)vp;
+  if ((wrapper == nullptr) || (i < 0) || (i >= wrapper->ptr->num_chunks())) {
+    return nullptr;
+  }
+
+  auto array = new Array;
+  array->ptr = wrapper->ptr->chunk(i);
+  return array;
+}
+
+// Concatenate chunks of column to a single array
+arrow::Status column_array(const std::shared_ptr<arrow::ChunkedArray> &column,
+                           std::shared_ptr<arrow::Array> *out) {
+  auto chunks = column->chunks();
+  if (chunks.size() == 1) {
+    *out = chunks[0];
+    return arrow::Status::OK();
+  }
+
+  if (chunks.size() == 0) {
+    return arrow::MakeArrayOfNull(column->type(), 0, out);
+  }
+
+  return arrow::Concatenate(chunks, arrow::default_memory_pool(), out);
+}
+
+result_t chunked_array_combine(void *vp) {
+  auto wrapper = (ChunkedArray *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::shared_ptr<arrow::Array> out;
+  auto status = column_array(wrapper->ptr, &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto array = new Array;
+  array->ptr = out;
+  return result_t{nullptr, array};
+}
+
+result_t chunked_array_slice(void *vp, int64_t offset, int64_t length) {
+  auto wrapper = (ChunkedArray *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  // Arrow checks bounds only in debug builds
+  if ((offset < 0) || (length < 0) ||
+      (offset + length > wrapper->ptr->length())) {
+    return result_t{strdup("slice out of range"), nullptr};
+  }
+
+  auto chunked = new ChunkedArray;
+  chunked->ptr = wrapper->ptr->Slice(offset, length);
+  return result_t{nullptr, chunked};
+}
+
+result_t chunked_array_equal(void *vp, void *op) {
+  auto wrapper = (ChunkedArray *)vp;
+  auto other = (ChunkedArray *)op;
+  if ((wrapper == nullptr) || (other == nullptr)) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  result_t res = {nullptr, nullptr};
+  res.i = wrapper->ptr->Equals(other->ptr) ? 1 : 0;
+  return res;
+}
+
+result_t chunked_array_to_string(void *vp) {
+  auto wrapper = (ChunkedArray *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::ostringstream oss;
+  auto status =
+      arrow::PrettyPrint(*wrapper->ptr, arrow::PrettyPrintOptions(0), &oss);
+  CARROW_RETURN_IF_ERROR(status);
+  return result_t{nullptr, strdup(oss.str().c_str())};
+}
+
+void chunked_array_free(void *vp) {
+  if (vp == nullptr) {
+    return;
+  }
+
+  delete (ChunkedArray *)vp;
+}
+
 
 void *table_new(void *sp, void *ap, size_t ncols) {
   auto schema = (Schema *)sp;
@@ -403,14 +687,30 @@ void *table_schema(void *vp) {
 
 void *table_column(void *vp, int i) {
   auto wrapper = (Table *)vp;
-  auto arr = wrapper->ptr->column(i);
-  if (arr == NULL) {
+  if ((i < 0) || (i >= wrapper->ptr->num_columns())) {
     return NULL;
   }
 
-  auto array = new Array;
-  array->ptr = arr->chunk(0); // FIXME: Rethink Array
-  return array;
+  ChunkedArray chunked;
+  chunked.ptr = wrapper->ptr->column(i);
+  auto res = chunked_array_combine(&chunked);
+  if (res.err != nullptr) {
+    free((void *)res.err);
+    return NULL;
+  }
+
+  return res.ptr;
+}
+
+void *table_chunked_column(void *vp, int i) {
+  auto wrapper = (Table *)vp;
+  if ((i < 0) || (i >= wrapper->ptr->num_columns())) {
+    return NULL;
+  }
+
+  auto chunked = new ChunkedArray;
+  chunked->ptr = wrapper->ptr->column(i);
+  return chunked;
 }
 
 void *table_field(void *vp, int i) {
@@ -440,6 +740,2016 @@ void *table_slice(void *vp, int64_t offset, int64_t length) {
   return table;
 }
 
+std::shared_ptr<arrow::Field> field_copy(arrow::Field *field) {
+  return std::make_shared<arrow::Field>(field->name(), field->type(),
+                                        field->nullable(), field->metadata());
+}
+
+result_t table_add_column(void *vp, int i, void *fp, void *ap) {
+  auto wrapper = (Table *)vp;
+  auto field = (arrow::Field *)fp;
+  auto array = (Array *)ap;
+  if ((wrapper == nullptr) || (field == nullptr) || (array == nullptr)) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto column = std::make_shared<arrow::ChunkedArray>(array->ptr);
+  std::shared_ptr<arrow::Table> out;
+  auto status = wrapper->ptr->AddColumn(i, field_copy(field), column, &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto table = new Table;
+  table->ptr = out;
+  return result_t{nullptr, table};
+}
+
+result_t table_remove_column(void *vp, int i) {
+  auto wrapper = (Table *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::shared_ptr<arrow::Table> out;
+  auto status = wrapper->ptr->RemoveColumn(i, &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto table = new Table;
+  table->ptr = out;
+  return result_t{nullptr, table};
+}
+
+result_t table_set_column(void *vp, int i, void *fp, void *ap) {
+  auto wrapper = (Table *)vp;
+  auto field = (arrow::Field *)fp;
+  auto array = (Array *)ap;
+  if ((wrapper == nullptr) || (field == nullptr) || (array == nullptr)) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto column = std::make_shared<arrow::ChunkedArray>(array->ptr);
+  std::shared_ptr<arrow::Table> out;
+  auto status = wrapper->ptr->SetColumn(i, field_copy(field), column, &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto table = new Table;
+  table->ptr = out;
+  return result_t{nullptr, table};
+}
+
+result_t table_select_columns(void *vp, int *indices, size_t count) {
+  auto wrapper = (Table *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::vector<std::shared_ptr<arrow::Field>> fields;
+  std::vector<std::shared_ptr<arrow::ChunkedArray>> columns;
+  for (size_t n = 0; n < count; n++) {
+    auto i = indices[n];
+    if ((i < 0) || (i >= wrapper->ptr->num_columns())) {
+      std::ostringstream oss;
+      oss << "column index out of range: " << i;
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+    fields.push_back(wrapper->ptr->field(i));
+    columns.push_back(wrapper->ptr->column(i));
+  }
+
+  auto schema =
+      std::make_shared<arrow::Schema>(fields, wrapper->ptr->schema()->metadata());
+  auto table = new Table;
+  table->ptr = arrow::Table::Make(schema, columns, wrapper->ptr->num_rows());
+  return result_t{nullptr, table};
+}
+
+result_t table_rename_columns(void *vp, char **names, size_t count) {
+  auto wrapper = (Table *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::vector<std::string> vec;
+  for (size_t i = 0; i < count; i++) {
+    vec.push_back(names[i]);
+  }
+
+  std::shared_ptr<arrow::Table> out;
+  auto status = wrapper->ptr->RenameColumns(vec, &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto table = new Table;
+  table->ptr = out;
+  return result_t{nullptr, table};
+}
+
+result_t table_concatenate(void *vp, size_t count, int unify_schemas) {
+  auto wrappers = (Table **)vp;
+  std::vector<std::shared_ptr<arrow::Table>> tables;
+  for (size_t i = 0; i < count; i++) {
+    tables.push_back(wrappers[i]->ptr);
+  }
+
+  auto options = arrow::ConcatenateTablesOptions::Defaults();
+  options.unify_schemas = unify_schemas != 0;
+  auto out = arrow::ConcatenateTables(tables, options);
+  CARROW_RETURN_IF_ERROR(out.status());
+
+  auto table = new Table;
+  table->ptr = out.ValueOrDie();
+  return result_t{nullptr, table};
+}
+
+result_t table_combine_chunks(void *vp) {
+  auto wrapper = (Table *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::shared_ptr<arrow::Table> out;
+  auto status =
+      wrapper->ptr->CombineChunks(arrow::default_memory_pool(), &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto table = new Table;
+  table->ptr = out;
+  return result_t{nullptr, table};
+}
+
+result_t table_equal(void *vp, void *op) {
+  auto wrapper = (Table *)vp;
+  auto other = (Table *)op;
+  if ((wrapper == nullptr) || (other == nullptr)) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  result_t res = {nullptr, nullptr};
+  res.i = wrapper->ptr->Equals(*other->ptr) ? 1 : 0;
+  return res;
+}
+
+result_t table_to_string(void *vp) {
+  auto wrapper = (Table *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::ostringstream oss;
+  auto status =
+      arrow::PrettyPrint(*wrapper->ptr, arrow::PrettyPrintOptions(0), &oss);
+  CARROW_RETURN_IF_ERROR(status);
+  return result_t{nullptr, strdup(oss.str().c_str())};
+}
+
+result_t table_from_batches(void *vp, size_t count) {
+  auto wrappers = (RecordBatch **)vp;
+  std::vector<std::shared_ptr<arrow::RecordBatch>> batches;
+  for (size_t i = 0; i < count; i++) {
+    batches.push_back(wrappers[i]->ptr);
+  }
+
+  std::shared_ptr<arrow::Table> out;
+  auto status = arrow::Table::FromRecordBatches(batches, &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto table = new Table;
+  table->ptr = out;
+  return result_t{nullptr, table};
+}
+
+result_t table_batch_reader(void *vp, int64_t max_chunksize) {
+  auto wrapper = (Table *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto rdr = new BatchReader;
+  rdr->table = wrapper->ptr;
+  rdr->reader = std::make_shared<arrow::TableBatchReader>(*rdr->table);
+  if (max_chunksize > 0) {
+    rdr->reader->set_chunksize(max_chunksize);
+  }
+
+  return result_t{nullptr, rdr};
+}
+
+// ptr is nullptr at end of table
+result_t batch_reader_next(void *vp) {
+  auto rdr = (BatchReader *)vp;
+  if (rdr == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::shared_ptr<arrow::RecordBatch> out;
+  auto status = rdr->reader->ReadNext(&out);
+  CARROW_RETURN_IF_ERROR(status);
+  if (out == nullptr) {
+    return result_t{nullptr, nullptr};
+  }
+
+  auto batch = new RecordBatch;
+  batch->ptr = out;
+  return result_t{nullptr, batch};
+}
+
+void batch_reader_free(void *vp) {
+  if (vp == nullptr) {
+    return;
+  }
+
+  delete (BatchReader *)vp;
+}
+
+long long record_batch_num_cols(void *vp) {
+  auto wrapper = (RecordBatch *)vp;
+  return wrapper->ptr->num_columns();
+}
+
+long long record_batch_num_rows(void *vp) {
+  auto wrapper = (RecordBatch *)vp;
+  return wrapper->ptr->num_rows();
+}
+
+void *record_batch_schema(void *vp) {
+  auto wrapper = (RecordBatch *)vp;
+  auto ptr = wrapper->ptr->schema();
+  if (ptr == nullptr) {
+    return nullptr;
+  }
+
+  auto schema = new Schema;
+  schema->ptr = ptr;
+  return schema;
+}
+
+void *record_batch_column(void *vp, int i) {
+  auto wrapper = (RecordBatch *)vp;
+  if ((i < 0) || (i >= wrapper->ptr->num_columns())) {
+    return nullptr;
+  }
+
+  auto array = new Array;
+  array->ptr = wrapper->ptr->column(i);
+  return array;
+}
+
+void *record_batch_field(void *vp, int i) {
+  auto wrapper = (RecordBatch *)vp;
+  if ((i < 0) || (i >= wrapper->ptr->num_columns())) {
+    return nullptr;
+  }
+
+  return wrapper->ptr->schema()->field(i).get();
+}
+
+void *record_batch_slice(void *vp, int64_t offset, int64_t length) {
+  auto wrapper = (RecordBatch *)vp;
+  auto batch = new RecordBatch;
+  batch->ptr = wrapper->ptr->Slice(offset, length);
+  return batch;
+}
+
+void record_batch_free(void *vp) {
+  if (vp == nullptr) {
+    return;
+  }
+
+  delete (RecordBatch *)vp;
+}
+
+arrow::Datum make_datum(void *vp, int kind) {
+  if (kind == CHUNKED_ARRAY_DATUM) {
+    return arrow::Datum(((ChunkedArray *)vp)->ptr);
+  }
+
+  return arrow::Datum(((Array *)vp)->ptr);
+}
+
+arrow::ArrayVector datum_chunks(const arrow::Datum &datum) {
+  if (datum.kind() == arrow::Datum::CHUNKED_ARRAY) {
+    return datum.chunked_array()->chunks();
+  }
+
+  return arrow::ArrayVector{datum.make_array()};
+}
+
+scalar_t scalar_from(const std::shared_ptr<arrow::Scalar> &scalar) {
+  scalar_t res = {nullptr, -1, 0, 0};
+  if ((scalar == nullptr) || !scalar->is_valid) {
+    return res;
+  }
+
+  switch (scalar->type->id()) {
+  case arrow::Type::BOOL:
+    res.dtype = BOOL_DTYPE;
+    res.i = ((arrow::BooleanScalar *)scalar.get())->value ? 1 : 0;
+    break;
+  case arrow::Type::INT64:
+    res.dtype = INTEGER64_DTYPE;
+    res.i = ((arrow::Int64Scalar *)scalar.get())->value;
+    break;
+  case arrow::Type::UINT64:
+    res.dtype = INTEGER64_DTYPE;
+    res.i = ((arrow::UInt64Scalar *)scalar.get())->value;
+    break;
+  case arrow::Type::DOUBLE:
+    res.dtype = FLOAT64_DTYPE;
+    res.f = ((arrow::DoubleScalar *)scalar.get())->value;
+    break;
+  case arrow::Type::TIMESTAMP:
+    res.dtype = TIMESTAMP_DTYPE;
+    res.i = timestamp_nanos(*scalar->type,
+                            ((arrow::TimestampScalar *)scalar.get())->value);
+    break;
+  default:
+    std::ostringstream oss;
+    oss << "unsupported scalar type: " << scalar->type->ToString();
+    res.err = strdup(oss.str().c_str());
+  }
+
+  return res;
+}
+
+scalar_t compute_sum(void *vp, int kind) {
+  if (vp == nullptr) {
+    return scalar_t{strdup("null pointer"), -1};
+  }
+
+  arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+  arrow::Datum out;
+  auto status = arrow::compute::Sum(&ctx, make_datum(vp, kind), &out);
+  CARROW_RETURN_SCALAR_IF_ERROR(status);
+  return scalar_from(out.scalar());
+}
+
+scalar_t compute_mean(void *vp, int kind) {
+  if (vp == nullptr) {
+    return scalar_t{strdup("null pointer"), -1};
+  }
+
+  arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+  arrow::Datum out;
+  auto status = arrow::compute::Mean(&ctx, make_datum(vp, kind), &out);
+  CARROW_RETURN_SCALAR_IF_ERROR(status);
+  return scalar_from(out.scalar());
+}
+
+// Returns the minimal value, the maximal value is stored in max
+scalar_t compute_min_max(void *vp, int kind, int skip_nulls, scalar_t *max) {
+  if ((vp == nullptr) || (max == nullptr)) {
+    return scalar_t{strdup("null pointer"), -1};
+  }
+
+  arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+  arrow::compute::MinMaxOptions options(
+      skip_nulls ? arrow::compute::MinMaxOptions::SKIP
+                 : arrow::compute::MinMaxOptions::OUTPUT_NULL);
+  arrow::Datum out;
+  auto status =
+      arrow::compute::MinMax(&ctx, options, make_datum(vp, kind), &out);
+  CARROW_RETURN_SCALAR_IF_ERROR(status);
+
+  auto values = out.collection();
+  *max = scalar_from(values[1].scalar());
+  return scalar_from(values[0].scalar());
+}
+
+scalar_t compute_count(void *vp, int kind, int mode) {
+  if (vp == nullptr) {
+    return scalar_t{strdup("null pointer"), -1};
+  }
+
+  arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+  arrow::compute::CountOptions options(
+      (mode == COUNT_NULLS) ? arrow::compute::CountOptions::COUNT_NULL
+                            : arrow::compute::CountOptions::COUNT_ALL);
+  arrow::Datum out;
+  auto status =
+      arrow::compute::Count(&ctx, options, make_datum(vp, kind), &out);
+  CARROW_RETURN_SCALAR_IF_ERROR(status);
+  return scalar_from(out.scalar());
+}
+
+// Arrow has no variance kernel yet, we use Welford's algorithm over the chunks
+scalar_t compute_variance(void *vp, int kind, int ddof) {
+  if (vp == nullptr) {
+    return scalar_t{strdup("null pointer"), -1};
+  }
+
+  int64_t count = 0;
+  double mean = 0, m2 = 0;
+  for (auto chunk : datum_chunks(make_datum(vp, kind))) {
+    auto dtype = chunk->type_id();
+    if ((dtype != INTEGER64_DTYPE) && (dtype != FLOAT64_DTYPE)) {
+      std::ostringstream oss;
+      oss << "variance: unsupported type: " << chunk->type()->ToString();
+      return scalar_t{strdup(oss.str().c_str()), -1};
+    }
+
+    for (int64_t i = 0; i < chunk->length(); i++) {
+      if (chunk->IsNull(i)) {
+        continue;
+      }
+
+      double value;
+      if (dtype == INTEGER64_DTYPE) {
+        value = ((arrow::Int64Array *)chunk.get())->Value(i);
+      } else {
+        value = ((arrow::DoubleArray *)chunk.get())->Value(i);
+      }
+
+      count++;
+      auto delta = value - mean;
+      mean += delta / count;
+      m2 += delta * (value - mean);
+    }
+  }
+
+  scalar_t res = {nullptr, -1, 0, 0};
+  if (count - ddof <= 0) {
+    return res;
+  }
+
+  res.dtype = FLOAT64_DTYPE;
+  res.f = m2 / (count - ddof);
+  return res;
+}
+
+scalar_t compute_stddev(void *vp, int kind, int ddof) {
+  auto res = compute_variance(vp, kind, ddof);
+  if (res.dtype == FLOAT64_DTYPE) {
+    res.f = std::sqrt(res.f);
+  }
+  return res;
+}
+
+typedef std::function<arrow::Status(const std::shared_ptr<arrow::Array> &,
+                                    std::shared_ptr<arrow::Array> *)>
+    array_op_t;
+
+// Apply op on array, returns a new array
+result_t array_apply(void *vp, array_op_t op) {
+  auto wrapper = (Array *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::shared_ptr<arrow::Array> out;
+  auto status = op(wrapper->ptr, &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto array = new Array;
+  array->ptr = out;
+  return result_t{nullptr, array};
+}
+
+arrow::compute::FilterOptions filter_options(bool emit_nulls) {
+  arrow::compute::FilterOptions options;
+  options.null_selection_behavior =
+      emit_nulls ? arrow::compute::FilterOptions::EMIT_NULL
+                 : arrow::compute::FilterOptions::DROP;
+  return options;
+}
+
+arrow::Status filter_array(const std::shared_ptr<arrow::Array> &values,
+                           const std::shared_ptr<arrow::Array> &mask,
+                           bool emit_nulls,
+                           std::shared_ptr<arrow::Array> *out) {
+  arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+  arrow::Datum datum;
+  auto status =
+      arrow::compute::Filter(&ctx, arrow::Datum(values), arrow::Datum(mask),
+                             filter_options(emit_nulls), &datum);
+  if (!status.ok()) {
+    return status;
+  }
+
+  *out = datum.make_array();
+  return arrow::Status::OK();
+}
+
+arrow::Status take_array(const std::shared_ptr<arrow::Array> &values,
+                         const std::shared_ptr<arrow::Array> &indices,
+                         std::shared_ptr<arrow::Array> *out) {
+  arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+  arrow::compute::TakeOptions options;
+  arrow::Datum datum;
+  auto status = arrow::compute::Take(&ctx, arrow::Datum(values),
+                                     arrow::Datum(indices), options, &datum);
+  if (!status.ok()) {
+    return status;
+  }
+
+  *out = datum.make_array();
+  return arrow::Status::OK();
+}
+
+arrow::Status drop_nulls(const std::shared_ptr<arrow::Array> &values,
+                         std::shared_ptr<arrow::Array> *out) {
+  if (values->null_count() == 0) {
+    *out = values;
+    return arrow::Status::OK();
+  }
+
+  arrow::BooleanBuilder builder;
+  for (int64_t i = 0; i < values->length(); i++) {
+    auto status = builder.Append(values->IsValid(i));
+    if (!status.ok()) {
+      return status;
+    }
+  }
+
+  std::shared_ptr<arrow::Array> mask;
+  auto status = builder.Finish(&mask);
+  if (!status.ok()) {
+    return status;
+  }
+
+  return filter_array(values, mask, false, out);
+}
+
+result_t compute_filter(void *vp, int kind, void *mp, int emit_nulls) {
+  auto mask = (Array *)mp;
+  if (mask == nullptr) {
+    return result_t{strdup("null mask"), nullptr};
+  }
+
+  if (kind == TABLE_DATUM) {
+    auto wrapper = (Table *)vp;
+    if (wrapper == nullptr) {
+      return result_t{strdup("null pointer"), nullptr};
+    }
+
+    // Filter every column chunk by chunk, no concatenation
+    arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+    std::shared_ptr<arrow::Table> out;
+    auto status = arrow::compute::Filter(&ctx, *wrapper->ptr, *mask->ptr,
+                                         filter_options(emit_nulls), &out);
+    CARROW_RETURN_IF_ERROR(status);
+
+    auto table = new Table;
+    table->ptr = out;
+    return result_t{nullptr, table};
+  }
+
+  auto op = [mask, emit_nulls](const std::shared_ptr<arrow::Array> &values,
+                               std::shared_ptr<arrow::Array> *out) {
+    return filter_array(values, mask->ptr, emit_nulls != 0, out);
+  };
+  return array_apply(vp, op);
+}
+
+result_t compute_take(void *vp, int kind, void *ip, int drop_null_indices) {
+  auto wrapper = (Array *)ip;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null indices"), nullptr};
+  }
+
+  auto indices = wrapper->ptr;
+  if (drop_null_indices) {
+    auto status = drop_nulls(wrapper->ptr, &indices);
+    CARROW_RETURN_IF_ERROR(status);
+  }
+
+  if (kind == TABLE_DATUM) {
+    auto tp = (Table *)vp;
+    if (tp == nullptr) {
+      return result_t{strdup("null pointer"), nullptr};
+    }
+
+    // Take from every column chunk by chunk, no concatenation
+    arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+    arrow::compute::TakeOptions options;
+    std::shared_ptr<arrow::Table> out;
+    auto status =
+        arrow::compute::Take(&ctx, *tp->ptr, *indices, options, &out);
+    CARROW_RETURN_IF_ERROR(status);
+
+    auto table = new Table;
+    table->ptr = out;
+    return result_t{nullptr, table};
+  }
+
+  auto op = [indices](const std::shared_ptr<arrow::Array> &values,
+                      std::shared_ptr<arrow::Array> *out) {
+    return take_array(values, indices, out);
+  };
+  return array_apply(vp, op);
+}
+
+std::shared_ptr<arrow::Scalar> make_scalar(const scalar_t *scalar) {
+  switch (scalar->dtype) {
+  case BOOL_DTYPE:
+    return std::make_shared<arrow::BooleanScalar>(scalar->i != 0);
+  case INTEGER64_DTYPE:
+    return std::make_shared<arrow::Int64Scalar>(scalar->i);
+  case FLOAT64_DTYPE:
+    return std::make_shared<arrow::DoubleScalar>(scalar->f);
+  case STRING_DTYPE:
+    return std::make_shared<arrow::StringScalar>(std::string(scalar->s));
+  case TIMESTAMP_DTYPE:
+    return std::make_shared<arrow::TimestampScalar>(
+        scalar->i, data_type(TIMESTAMP_DTYPE));
+  }
+
+  return nullptr;
+}
+
+result_t compute_compare(void *vp, int op, void *op_array, scalar_t *scalar) {
+  auto wrapper = (Array *)vp;
+  if ((wrapper == nullptr) || ((op_array == nullptr) && (scalar == nullptr))) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  arrow::compute::CompareOperator cmp;
+  switch (op) {
+  case CMP_EQUAL:
+    cmp = arrow::compute::CompareOperator::EQUAL;
+    break;
+  case CMP_NOT_EQUAL:
+    cmp = arrow::compute::CompareOperator::NOT_EQUAL;
+    break;
+  case CMP_LESS:
+    cmp = arrow::compute::CompareOperator::LESS;
+    break;
+  case CMP_LESS_EQUAL:
+    cmp = arrow::compute::CompareOperator::LESS_EQUAL;
+    break;
+  case CMP_GREATER:
+    cmp = arrow::compute::CompareOperator::GREATER;
+    break;
+  case CMP_GREATER_EQUAL:
+    cmp = arrow::compute::CompareOperator::GREATER_EQUAL;
+    break;
+  default:
+    std::ostringstream oss;
+    oss << "unknown compare operator: " << op;
+    return result_t{strdup(oss.str().c_str()), nullptr};
+  }
+
+  arrow::Datum right;
+  if (op_array != nullptr) {
+    auto other = ((Array *)op_array)->ptr;
+    if (other->length() != wrapper->ptr->length()) {
+      std::ostringstream oss;
+      oss << "length mismatch: " << wrapper->ptr->length()
+          << " != " << other->length();
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+    std::shared_ptr<arrow::Array> nanos;
+    auto status = timestamps_as_nanos(other, &nanos);
+    CARROW_RETURN_IF_ERROR(status);
+    right = arrow::Datum(nanos);
+  } else {
+    auto value = make_scalar(scalar);
+    if (value == nullptr) {
+      return result_t{strdup("unsupported scalar type"), nullptr};
+    }
+    right = arrow::Datum(value);
+  }
+
+  // Go time.Time values are timestamp[ns]
+  std::shared_ptr<arrow::Array> left;
+  auto status = timestamps_as_nanos(wrapper->ptr, &left);
+  CARROW_RETURN_IF_ERROR(status);
+
+  arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+  arrow::Datum out;
+  status = arrow::compute::Compare(&ctx, arrow::Datum(left), right,
+                                   arrow::compute::CompareOptions(cmp), &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto array = new Array;
+  array->ptr = out.make_array();
+  return result_t{nullptr, array};
+}
+
+// Numeric (int64 or float64) operand of arithmetic functions, either an array
+// or a scalar
+struct NumericOperand {
+  std::shared_ptr<arrow::Array> array;
+  const scalar_t *scalar;
+
+  int dtype() const {
+    return (array != nullptr) ? array->type_id() : scalar->dtype;
+  }
+
+  bool is_null(int64_t i) const {
+    return (array != nullptr) ? array->IsNull(i) : false;
+  }
+
+  int64_t int_at(int64_t i) const {
+    if (array == nullptr) {
+      return scalar->i;
+    }
+    return ((arrow::Int64Array *)array.get())->Value(i);
+  }
+
+  double float_at(int64_t i) const {
+    if (array == nullptr) {
+      return (scalar->dtype == FLOAT64_DTYPE) ? scalar->f : scalar->i;
+    }
+    if (dtype() == FLOAT64_DTYPE) {
+      return ((arrow::DoubleArray *)array.get())->Value(i);
+    }
+    return ((arrow::Int64Array *)array.get())->Value(i);
+  }
+};
+
+arrow::Status int_arithmetic(int op, int64_t a, int64_t b, int64_t *out) {
+  bool overflow = false;
+  switch (op) {
+  case ARITH_ADD:
+    overflow = __builtin_add_overflow(a, b, out);
+    break;
+  case ARITH_SUBTRACT:
+    overflow = __builtin_sub_overflow(a, b, out);
+    break;
+  case ARITH_MULTIPLY:
+    overflow = __builtin_mul_overflow(a, b, out);
+    break;
+  case ARITH_DIVIDE:
+    if (b == 0) {
+      return arrow::Status::Invalid("divide by zero");
+    }
+    if ((a == INT64_MIN) && (b == -1)) {
+      overflow = true;
+    } else {
+      *out = a / b;
+    }
+    break;
+  }
+
+  if (overflow) {
+    return arrow::Status::Invalid("integer overflow");
+  }
+  return arrow::Status::OK();
+}
+
+double float_arithmetic(int op, double a, double b) {
+  switch (op) {
+  case ARITH_ADD:
+    return a + b;
+  case ARITH_SUBTRACT:
+    return a - b;
+  case ARITH_MULTIPLY:
+    return a * b;
+  }
+  return a / b;
+}
+
+// Arrow has no checked arithmetic kernels yet, we implement them here for the
+// numeric types carrow supports
+result_t compute_arithmetic(void *vp, int op, void *op_array,
+                            scalar_t *scalar) {
+  auto wrapper = (Array *)vp;
+  if ((wrapper == nullptr) || ((op_array == nullptr) && (scalar == nullptr))) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  if ((op < ARITH_ADD) || (op > ARITH_DIVIDE)) {
+    std::ostringstream oss;
+    oss << "unknown arithmetic operator: " << op;
+    return result_t{strdup(oss.str().c_str()), nullptr};
+  }
+
+  NumericOperand left{wrapper->ptr, nullptr};
+  NumericOperand right{nullptr, scalar};
+  if (op_array != nullptr) {
+    right.array = ((Array *)op_array)->ptr;
+    if (right.array->length() != left.array->length()) {
+      return result_t{strdup("arrays have different lengths"), nullptr};
+    }
+  }
+
+  for (auto operand : {&left, &right}) {
+    auto dtype = operand->dtype();
+    if ((dtype != INTEGER64_DTYPE) && (dtype != FLOAT64_DTYPE)) {
+      std::ostringstream oss;
+      oss << "arithmetic: unsupported type: ";
+      if (operand->array != nullptr) {
+        oss << operand->array->type()->ToString();
+      } else {
+        oss << "scalar of dtype " << dtype;
+      }
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+  }
+
+  auto length = left.array->length();
+  std::shared_ptr<arrow::Array> out;
+  arrow::Status status;
+  if ((left.dtype() == INTEGER64_DTYPE) && (right.dtype() == INTEGER64_DTYPE)) {
+    arrow::Int64Builder builder;
+    for (int64_t i = 0; (i < length) && status.ok(); i++) {
+      if (left.is_null(i) || right.is_null(i)) {
+        status = builder.AppendNull();
+        continue;
+      }
+      int64_t value;
+      status = int_arithmetic(op, left.int_at(i), right.int_at(i), &value);
+      if (status.ok()) {
+        status = builder.Append(value);
+      }
+    }
+    CARROW_RETURN_IF_ERROR(status);
+    status = builder.Finish(&out);
+  } else {
+    arrow::DoubleBuilder builder;
+    for (int64_t i = 0; (i < length) && status.ok(); i++) {
+      if (left.is_null(i) || right.is_null(i)) {
+        status = builder.AppendNull();
+        continue;
+      }
+      auto value = float_arithmetic(op, left.float_at(i), right.float_at(i));
+      status = builder.Append(value);
+    }
+    CARROW_RETURN_IF_ERROR(status);
+    status = builder.Finish(&out);
+  }
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto array = new Array;
+  array->ptr = out;
+  return result_t{nullptr, array};
+}
+
+result_t compute_sort_indices(void *vp) {
+  auto wrapper = (Array *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+  std::shared_ptr<arrow::Array> indices;
+  auto status = arrow::compute::SortToIndices(&ctx, *wrapper->ptr, &indices);
+  CARROW_RETURN_IF_ERROR(status);
+
+  // SortToIndices returns uint64, carrow works with int64
+  std::shared_ptr<arrow::Array> out;
+  status = arrow::compute::Cast(&ctx, *indices, arrow::int64(),
+                                arrow::compute::CastOptions::Safe(), &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto array = new Array;
+  array->ptr = out;
+  return result_t{nullptr, array};
+}
+
+// Compare valid values at i and j, returns <0, 0 or >0
+int compare_values(const arrow::Array *arr, int64_t i, int64_t j) {
+  switch (arr->type_id()) {
+  case arrow::Type::BOOL: {
+    auto a = (arrow::BooleanArray *)arr;
+    return int(a->Value(i)) - int(a->Value(j));
+  }
+  case arrow::Type::INT64: {
+    auto a = (arrow::Int64Array *)arr;
+    return (a->Value(i) > a->Value(j)) - (a->Value(i) < a->Value(j));
+  }
+  case arrow::Type::DOUBLE: {
+    auto a = (arrow::DoubleArray *)arr;
+    return (a->Value(i) > a->Value(j)) - (a->Value(i) < a->Value(j));
+  }
+  case arrow::Type::STRING: {
+    auto a = (arrow::StringArray *)arr;
+    return a->GetView(i).compare(a->GetView(j));
+  }
+  case arrow::Type::TIMESTAMP: {
+    auto a = (arrow::TimestampArray *)arr;
+    return (a->Value(i) > a->Value(j)) - (a->Value(i) < a->Value(j));
+  }
+  default:
+    break;
+  }
+
+  return 0;
+}
+
+bool is_nan(const arrow::Array *arr, int64_t i) {
+  if (arr->type_id() != arrow::Type::DOUBLE) {
+    return false;
+  }
+  return std::isnan(((arrow::DoubleArray *)arr)->Value(i));
+}
+
+struct SortKey {
+  std::shared_ptr<arrow::Array> array;
+  bool descending;
+  bool nulls_first;
+};
+
+// Arrow's SortToIndices supports only a single array in ascending order, we
+// compute multi key sort indices here and use Take
+result_t table_sort_by(void *vp, int *columns, int *descending,
+                       int *nulls_first, size_t count) {
+  auto wrapper = (Table *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto table = wrapper->ptr;
+  std::vector<SortKey> keys;
+  for (size_t n = 0; n < count; n++) {
+    auto i = columns[n];
+    if ((i < 0) || (i >= table->num_columns())) {
+      std::ostringstream oss;
+      oss << "column index out of range: " << i;
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+
+    SortKey key;
+    auto status = column_array(table->column(i), &key.array);
+    CARROW_RETURN_IF_ERROR(status);
+    switch (key.array->type_id()) {
+    case arrow::Type::BOOL:
+    case arrow::Type::INT64:
+    case arrow::Type::DOUBLE:
+    case arrow::Type::STRING:
+    case arrow::Type::TIMESTAMP:
+      break;
+    default:
+      std::ostringstream oss;
+      oss << "can't sort by " << table->field(i)->name() << " of type "
+          << key.array->type()->ToString();
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+    key.descending = descending[n] != 0;
+    key.nulls_first = nulls_first[n] != 0;
+    keys.push_back(key);
+  }
+
+  std::vector<int64_t> order(table->num_rows());
+  for (size_t i = 0; i < order.size(); i++) {
+    order[i] = i;
+  }
+
+  std::stable_sort(order.begin(), order.end(), [&keys](int64_t i, int64_t j) {
+    for (auto &key : keys) {
+      auto inull = key.array->IsNull(i), jnull = key.array->IsNull(j);
+      if (inull || jnull) {
+        if (inull == jnull) {
+          continue;
+        }
+        return key.nulls_first ? inull : jnull;
+      }
+
+      // NaN is not ordered, place it after all numbers in both directions
+      auto inan = is_nan(key.array.get(), i), jnan = is_nan(key.array.get(), j);
+      if (inan || jnan) {
+        if (inan == jnan) {
+          continue;
+        }
+        return jnan;
+      }
+
+      auto cmp = compare_values(key.array.get(), i, j);
+      if (cmp != 0) {
+        return key.descending ? cmp > 0 : cmp < 0;
+      }
+    }
+    return false;
+  });
+
+  arrow::Int64Builder builder;
+  auto status = builder.AppendValues(order);
+  CARROW_RETURN_IF_ERROR(status);
+  std::shared_ptr<arrow::Array> indices;
+  status = builder.Finish(&indices);
+  CARROW_RETURN_IF_ERROR(status);
+
+  arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+  arrow::compute::TakeOptions options;
+  std::shared_ptr<arrow::Table> out;
+  status = arrow::compute::Take(&ctx, *wrapper->ptr, *indices, options, &out);
+  CARROW_RETURN_IF_ERROR(status);
+
+  auto table = new Table;
+  table->ptr = out;
+  return result_t{nullptr, table};
+}
+
+arrow::Status cast_array(const std::shared_ptr<arrow::Array> &values,
+                         int dtype, bool safe,
+                         std::shared_ptr<arrow::Array> *out) {
+  auto to_type = data_type(dtype);
+  if (to_type == nullptr) {
+    std::ostringstream oss;
+    oss << "unknown dtype: " << dtype;
+    return arrow::Status::Invalid(oss.str());
+  }
+
+  arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+  auto options = safe ? arrow::compute::CastOptions::Safe()
+                      : arrow::compute::CastOptions::Unsafe();
+  return arrow::compute::Cast(&ctx, *values, to_type, options, out);
+}
+
+result_t compute_cast(void *vp, int dtype, int safe) {
+  auto op = [dtype, safe](const std::shared_ptr<arrow::Array> &values,
+                          std::shared_ptr<arrow::Array> *out) {
+    return cast_array(values, dtype, safe != 0, out);
+  };
+  return array_apply(vp, op);
+}
+
+result_t table_cast_columns(void *vp, int *columns, int *dtypes, size_t count,
+                            int safe) {
+  auto wrapper = (Table *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto table = wrapper->ptr;
+  for (size_t n = 0; n < count; n++) {
+    auto i = columns[n];
+    if ((i < 0) || (i >= table->num_columns())) {
+      std::ostringstream oss;
+      oss << "column index out of range: " << i;
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+
+    std::shared_ptr<arrow::Array> values, out;
+    auto status = column_array(table->column(i), &values);
+    CARROW_RETURN_IF_ERROR(status);
+    status = cast_array(values, dtypes[n], safe != 0, &out);
+    if (!status.ok()) {
+      std::ostringstream oss;
+      oss << table->field(i)->name() << ": " << status.message();
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+
+    auto field = table->field(i)->WithType(out->type());
+    auto column = std::make_shared<arrow::ChunkedArray>(out);
+    status = table->SetColumn(i, field, column, &table);
+    CARROW_RETURN_IF_ERROR(status);
+  }
+
+  auto res = new Table;
+  res->ptr = table;
+  return result_t{nullptr, res};
+}
+
+result_t compute_unique(void *vp) {
+  auto op = [](const std::shared_ptr<arrow::Array> &values,
+               std::shared_ptr<arrow::Array> *out) {
+    arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+    return arrow::compute::Unique(&ctx, arrow::Datum(values), out);
+  };
+  return array_apply(vp, op);
+}
+
+// Returns a struct array with "values" and "counts" fields
+result_t compute_value_counts(void *vp) {
+  auto op = [](const std::shared_ptr<arrow::Array> &values,
+               std::shared_ptr<arrow::Array> *out) {
+    arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+    return arrow::compute::ValueCounts(&ctx, arrow::Datum(values), out);
+  };
+  return array_apply(vp, op);
+}
+
+result_t compute_is_in(void *vp, void *values) {
+  auto wrapper = (Array *)values;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null values"), nullptr};
+  }
+
+  auto op = [wrapper](const std::shared_ptr<arrow::Array> &left,
+                      std::shared_ptr<arrow::Array> *out) {
+    arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+    arrow::Datum datum;
+    auto status = arrow::compute::IsIn(&ctx, arrow::Datum(left),
+                                       arrow::Datum(wrapper->ptr), &datum);
+    if (!status.ok()) {
+      return status;
+    }
+
+    *out = datum.make_array();
+    return arrow::Status::OK();
+  };
+  return array_apply(vp, op);
+}
+
+result_t compute_dictionary_encode(void *vp) {
+  auto op = [](const std::shared_ptr<arrow::Array> &values,
+               std::shared_ptr<arrow::Array> *out) {
+    arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+    arrow::Datum datum;
+    auto status =
+        arrow::compute::DictionaryEncode(&ctx, arrow::Datum(values), &datum);
+    if (!status.ok()) {
+      return status;
+    }
+
+    *out = datum.make_array();
+    return arrow::Status::OK();
+  };
+  return array_apply(vp, op);
+}
+
+result_t array_dictionary(void *vp) {
+  auto op = [](const std::shared_ptr<arrow::Array> &values,
+               std::shared_ptr<arrow::Array> *out) {
+    if (values->type_id() != arrow::Type::DICTIONARY) {
+      return arrow::Status::TypeError("not a dictionary array");
+    }
+
+    *out = ((arrow::DictionaryArray *)values.get())->dictionary();
+    return arrow::Status::OK();
+  };
+  return array_apply(vp, op);
+}
+
+// Indices are cast to int64
+result_t array_dictionary_indices(void *vp) {
+  auto op = [](const std::shared_ptr<arrow::Array> &values,
+               std::shared_ptr<arrow::Array> *out) {
+    if (values->type_id() != arrow::Type::DICTIONARY) {
+      return arrow::Status::TypeError("not a dictionary array");
+    }
+
+    auto indices = ((arrow::DictionaryArray *)values.get())->indices();
+    return cast_array(indices, INTEGER64_DTYPE, true, out);
+  };
+  return array_apply(vp, op);
+}
+
+result_t array_struct_field(void *vp, int i) {
+  auto op = [i](const std::shared_ptr<arrow::Array> &values,
+                std::shared_ptr<arrow::Array> *out) {
+    if (values->type_id() != arrow::Type::STRUCT) {
+      return arrow::Status::TypeError("not a struct array");
+    }
+
+    auto arr = (arrow::StructArray *)values.get();
+    if ((i < 0) || (i >= arr->num_fields())) {
+      return arrow::Status::IndexError("struct field out of range");
+    }
+
+    *out = arr->field(i);
+    return arrow::Status::OK();
+  };
+  return array_apply(vp, op);
+}
+
+// Append binary representation of value at i to out, used as hash key
+void encode_value(const arrow::Array *arr, int64_t i, std::string *out) {
+  if (arr->IsNull(i)) {
+    out->push_back('\0');
+    return;
+  }
+
+  out->push_back('\1');
+  switch (arr->type_id()) {
+  case arrow::Type::BOOL:
+    out->push_back(((arrow::BooleanArray *)arr)->Value(i) ? '\1' : '\0');
+    break;
+  case arrow::Type::INT64: {
+    auto v = ((arrow::Int64Array *)arr)->Value(i);
+    out->append((const char *)&v, sizeof(v));
+    break;
+  }
+  case arrow::Type::DOUBLE: {
+    auto v = ((arrow::DoubleArray *)arr)->Value(i);
+    // -0.0 == 0.0 and all NaNs are one group, normalize their bits
+    if (v == 0) {
+      v = 0;
+    } else if (std::isnan(v)) {
+      v = std::numeric_limits<double>::quiet_NaN();
+    }
+    out->append((const char *)&v, sizeof(v));
+    break;
+  }
+  case arrow::Type::TIMESTAMP: {
+    auto v = ((arrow::TimestampArray *)arr)->Value(i);
+    out->append((const char *)&v, sizeof(v));
+    break;
+  }
+  case arrow::Type::STRING: {
+    auto v = ((arrow::StringArray *)arr)->GetView(i);
+    int64_t size = v.size();
+    out->append((const char *)&size, sizeof(size));
+    out->append(v.data(), v.size());
+    break;
+  }
+  default:
+    break;
+  }
+}
+
+bool is_groupable(arrow::Type::type id) {
+  switch (id) {
+  case arrow::Type::BOOL:
+  case arrow::Type::INT64:
+  case arrow::Type::DOUBLE:
+  case arrow::Type::STRING:
+  case arrow::Type::TIMESTAMP:
+    return true;
+  default:
+    break;
+  }
+  return false;
+}
+
+// Output type of aggregation func on values of type, nullptr if not supported
+std::shared_ptr<arrow::DataType>
+aggregate_type(int func, const std::shared_ptr<arrow::DataType> &type) {
+  auto id = type->id();
+  switch (func) {
+  case AGG_COUNT:
+    return arrow::int64();
+  case AGG_COUNT_DISTINCT:
+    return is_groupable(id) ? arrow::int64() : nullptr;
+  case AGG_SUM:
+    if (id == arrow::Type::INT64) {
+      return arrow::int64();
+    }
+    return (id == arrow::Type::DOUBLE) ? arrow::float64() : nullptr;
+  case AGG_MEAN:
+    if ((id == arrow::Type::INT64) || (id == arrow::Type::DOUBLE)) {
+      return arrow::float64();
+    }
+    return nullptr;
+  case AGG_MIN:
+  case AGG_MAX:
+    if ((id == arrow::Type::INT64) || (id == arrow::Type::DOUBLE) ||
+        (id == arrow::Type::STRING) || (id == arrow::Type::TIMESTAMP)) {
+      return type;
+    }
+    return nullptr;
+  }
+
+  return nullptr;
+}
+
+struct GroupAccumulator {
+  int64_t count = 0; // non-null values
+  int64_t isum = 0;
+  double fsum = 0;
+  int64_t imin = 0, imax = 0;
+  double fmin = 0, fmax = 0;
+  std::string smin, smax;
+  std::unordered_set<std::string> distinct;
+};
+
+arrow::Status accumulate(int func, const arrow::Array *arr, int64_t i,
+                         GroupAccumulator *acc) {
+  if (arr->IsNull(i)) {
+    return arrow::Status::OK();
+  }
+
+  auto first = acc->count == 0;
+  acc->count++;
+  if (func == AGG_COUNT) {
+    return arrow::Status::OK();
+  }
+
+  if (func == AGG_COUNT_DISTINCT) {
+    std::string key;
+    encode_value(arr, i, &key);
+    acc->distinct.insert(key);
+    return arrow::Status::OK();
+  }
+
+  switch (arr->type_id()) {
+  case arrow::Type::INT64:
+  case arrow::Type::TIMESTAMP: {
+    int64_t v;
+    if (arr->type_id() == arrow::Type::INT64) {
+      v = ((arrow::Int64Array *)arr)->Value(i);
+    } else {
+      v = ((arrow::TimestampArray *)arr)->Value(i);
+    }
+    if ((func == AGG_SUM) && __builtin_add_overflow(acc->isum, v, &acc->isum)) {
+      return arrow::Status::Invalid("integer overflow");
+    }
+    acc->fsum += v;
+    acc->imin = (first || (v < acc->imin)) ? v : acc->imin;
+    acc->imax = (first || (v > acc->imax)) ? v : acc->imax;
+    break;
+  }
+  case arrow::Type::DOUBLE: {
+    auto v = ((arrow::DoubleArray *)arr)->Value(i);
+    acc->fsum += v;
+    acc->fmin = (first || (v < acc->fmin)) ? v : acc->fmin;
+    acc->fmax = (first || (v > acc->fmax)) ? v : acc->fmax;
+    break;
+  }
+  case arrow::Type::STRING: {
+    auto v = ((arrow::StringArray *)arr)->GetString(i);
+    acc->smin = (first || (v < acc->smin)) ? v : acc->smin;
+    acc->smax = (first || (v > acc->smax)) ? v : acc->smax;
+    break;
+  }
+  default:
+    break;
+  }
+
+  return arrow::Status::OK();
+}
+
+// Build output array for aggregation func, groups without values are null
+// (except for counts)
+arrow::Status finish_aggregate(int func,
+                               const std::shared_ptr<arrow::DataType> &type,
+                               const std::vector<GroupAccumulator> &accs,
+                               std::shared_ptr<arrow::Array> *out) {
+  std::unique_ptr<arrow::ArrayBuilder> builder;
+  auto status =
+      arrow::MakeBuilder(arrow::default_memory_pool(), type, &builder);
+  if (!status.ok()) {
+    return status;
+  }
+
+  for (auto &acc : accs) {
+    if ((func == AGG_COUNT) || (func == AGG_COUNT_DISTINCT)) {
+      int64_t n = (func == AGG_COUNT) ? acc.count : acc.distinct.size();
+      status = ((arrow::Int64Builder *)builder.get())->Append(n);
+    } else if (acc.count == 0) {
+      status = builder->AppendNull();
+    } else if (func == AGG_MEAN) {
+      auto mean = acc.fsum / acc.count;
+      status = ((arrow::DoubleBuilder *)builder.get())->Append(mean);
+    } else {
+      switch (type->id()) {
+      case arrow::Type::INT64: {
+        auto v = (func == AGG_SUM) ? acc.isum
+                                   : (func == AGG_MIN) ? acc.imin : acc.imax;
+        status = ((arrow::Int64Builder *)builder.get())->Append(v);
+        break;
+      }
+      case arrow::Type::TIMESTAMP: {
+        auto v = (func == AGG_MIN) ? acc.imin : acc.imax;
+        status = ((arrow::TimestampBuilder *)builder.get())->Append(v);
+        break;
+      }
+      case arrow::Type::DOUBLE: {
+        auto v = (func == AGG_SUM) ? acc.fsum
+                                   : (func == AGG_MIN) ? acc.fmin : acc.fmax;
+        status = ((arrow::DoubleBuilder *)builder.get())->Append(v);
+        break;
+      }
+      case arrow::Type::STRING: {
+        auto v = (func == AGG_MIN) ? acc.smin : acc.smax;
+        status = ((arrow::StringBuilder *)builder.get())->Append(v);
+        break;
+      }
+      default:
+        status = arrow::Status::NotImplemented("unsupported aggregate type");
+      }
+    }
+
+    if (!status.ok()) {
+      return status;
+    }
+  }
+
+  return builder->Finish(out);
+}
+
+// Arrow has no hash aggregation yet, rows are grouped by the binary encoding of
+// their key values. Groups are in order of first appearance.
+result_t table_group_by(void *vp, int *keys, size_t nkeys, int *columns,
+                        int *funcs, char **names, size_t naggs) {
+  auto wrapper = (Table *)vp;
+  if (wrapper == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto table = wrapper->ptr;
+  auto check_index = [&table](int i) {
+    if ((i < 0) || (i >= table->num_columns())) {
+      std::ostringstream oss;
+      oss << "column index out of range: " << i;
+      return arrow::Status::IndexError(oss.str());
+    }
+    return arrow::Status::OK();
+  };
+
+  std::vector<std::shared_ptr<arrow::Array>> key_arrays;
+  for (size_t n = 0; n < nkeys; n++) {
+    auto status = check_index(keys[n]);
+    CARROW_RETURN_IF_ERROR(status);
+    auto field = table->field(keys[n]);
+    if (!is_groupable(field->type()->id())) {
+      std::ostringstream oss;
+      oss << "can't group by " << field->name() << " of type "
+          << field->type()->ToString();
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+
+    std::shared_ptr<arrow::Array> arr;
+    status = column_array(table->column(keys[n]), &arr);
+    CARROW_RETURN_IF_ERROR(status);
+    key_arrays.push_back(arr);
+  }
+
+  std::vector<std::shared_ptr<arrow::Field>> fields;
+  std::vector<std::shared_ptr<arrow::Array>> agg_arrays;
+  for (size_t n = 0; n < naggs; n++) {
+    auto status = check_index(columns[n]);
+    CARROW_RETURN_IF_ERROR(status);
+    auto field = table->field(columns[n]);
+    auto out_type = aggregate_type(funcs[n], field->type());
+    if (out_type == nullptr) {
+      std::ostringstream oss;
+      oss << names[n] << ": unsupported aggregation on " << field->name()
+          << " of type " << field->type()->ToString();
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+
+    std::shared_ptr<arrow::Array> arr;
+    status = column_array(table->column(columns[n]), &arr);
+    CARROW_RETURN_IF_ERROR(status);
+    agg_arrays.push_back(arr);
+    fields.push_back(arrow::field(names[n], out_type));
+  }
+
+  auto nrows = table->num_rows();
+  std::unordered_map<std::string, int64_t> group_ids;
+  std::vector<int64_t> row_groups(nrows);
+  std::vector<int64_t> first_rows;
+  for (int64_t i = 0; i < nrows; i++) {
+    std::string key;
+    for (auto &arr : key_arrays) {
+      encode_value(arr.get(), i, &key);
+    }
+
+    auto it = group_ids.find(key);
+    if (it == group_ids.end()) {
+      it = group_ids.emplace(key, first_rows.size()).first;
+      first_rows.push_back(i);
+    }
+    row_groups[i] = it->second;
+  }
+
+  // Global aggregation (no keys) has a single group, even without rows
+  int64_t ngroups = first_rows.size();
+  if (nkeys == 0) {
+    ngroups = 1;
+  }
+
+  arrow::Int64Builder builder;
+  auto status = builder.AppendValues(first_rows);
+  CARROW_RETURN_IF_ERROR(status);
+  std::shared_ptr<arrow::Array> indices;
+  status = builder.Finish(&indices);
+  CARROW_RETURN_IF_ERROR(status);
+
+  std::vector<std::shared_ptr<arrow::Field>> out_fields;
+  std::vector<std::shared_ptr<arrow::Array>> out_arrays;
+  for (size_t n = 0; n < nkeys; n++) {
+    std::shared_ptr<arrow::Array> out;
+    status = take_array(key_arrays[n], indices, &out);
+    CARROW_RETURN_IF_ERROR(status);
+    out_fields.push_back(table->field(keys[n]));
+    out_arrays.push_back(out);
+  }
+
+  for (size_t n = 0; n < naggs; n++) {
+    std::vector<GroupAccumulator> accs(ngroups);
+    auto arr = agg_arrays[n].get();
+    for (int64_t i = 0; i < nrows; i++) {
+      status = accumulate(funcs[n], arr, i, &accs[row_groups[i]]);
+      CARROW_RETURN_IF_ERROR(status);
+    }
+
+    std::shared_ptr<arrow::Array> out;
+    status = finish_aggregate(funcs[n], fields[n]->type(), accs, &out);
+    CARROW_RETURN_IF_ERROR(status);
+    out_fields.push_back(fields[n]);
+    out_arrays.push_back(out);
+  }
+
+  auto schema = std::make_shared<arrow::Schema>(out_fields);
+  auto out = new Table;
+  out->ptr = arrow::Table::Make(schema, out_arrays, ngroups);
+  return result_t{nullptr, out};
+}
+
+// Encode key columns at row i to key, returns false if any key is null
+bool encode_key(const std::vector<std::shared_ptr<arrow::Array>> &arrays,
+                int64_t i, std::string *key) {
+  for (auto &arr : arrays) {
+    if (arr->IsNull(i)) {
+      return false;
+    }
+    encode_value(arr.get(), i, key);
+  }
+  return true;
+}
+
+// Arrow has no hash join yet, we build a hash table on the right table keys
+// and probe it with the left table rows. Null keys never match.
+result_t table_join(void *lp, void *rp, int *left_keys, int *right_keys,
+                    size_t nkeys, int join_type, const char *left_suffix,
+                    const char *right_suffix) {
+  auto lwrapper = (Table *)lp;
+  auto rwrapper = (Table *)rp;
+  if ((lwrapper == nullptr) || (rwrapper == nullptr)) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto left = lwrapper->ptr, right = rwrapper->ptr;
+  std::vector<std::shared_ptr<arrow::Array>> lkeys, rkeys;
+  for (size_t n = 0; n < nkeys; n++) {
+    auto li = left_keys[n], ri = right_keys[n];
+    if ((li < 0) || (li >= left->num_columns()) || (ri < 0) ||
+        (ri >= right->num_columns())) {
+      return result_t{strdup("key column index out of range"), nullptr};
+    }
+
+    auto lfield = left->field(li), rfield = right->field(ri);
+    if (!is_groupable(lfield->type()->id()) ||
+        !lfield->type()->Equals(rfield->type())) {
+      std::ostringstream oss;
+      oss << "can't join " << lfield->name() << " ("
+          << lfield->type()->ToString() << ") with " << rfield->name() << " ("
+          << rfield->type()->ToString() << ")";
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+
+    std::shared_ptr<arrow::Array> larr, rarr;
+    auto status = column_array(left->column(li), &larr);
+    CARROW_RETURN_IF_ERROR(status);
+    status = column_array(right->column(ri), &rarr);
+    CARROW_RETURN_IF_ERROR(status);
+    lkeys.push_back(larr);
+    rkeys.push_back(rarr);
+  }
+
+  std::unordered_map<std::string, std::vector<int64_t>> index;
+  for (int64_t i = 0; i < right->num_rows(); i++) {
+    std::string key;
+    if (encode_key(rkeys, i, &key)) {
+      index[key].push_back(i);
+    }
+  }
+
+  arrow::Int64Builder lbuilder, rbuilder;
+  std::vector<bool> right_matched(right->num_rows(), false);
+  arrow::Status status;
+  for (int64_t i = 0; (i < left->num_rows()) && status.ok(); i++) {
+    std::string key;
+    auto it = index.end();
+    if (encode_key(lkeys, i, &key)) {
+      it = index.find(key);
+    }
+    auto matched = it != index.end();
+
+    switch (join_type) {
+    case JOIN_SEMI:
+    case JOIN_ANTI:
+      if (matched == (join_type == JOIN_SEMI)) {
+        status = lbuilder.Append(i);
+      }
+      break;
+    default:
+      if (matched) {
+        for (auto j : it->second) {
+          right_matched[j] = true;
+          status = lbuilder.Append(i);
+          if (status.ok()) {
+            status = rbuilder.Append(j);
+          }
+        }
+      } else if (join_type != JOIN_INNER) {
+        status = lbuilder.Append(i);
+        if (status.ok()) {
+          status = rbuilder.AppendNull();
+        }
+      }
+    }
+  }
+  CARROW_RETURN_IF_ERROR(status);
+
+  if (join_type == JOIN_OUTER) {
+    for (int64_t j = 0; (j < right->num_rows()) && status.ok(); j++) {
+      if (!right_matched[j]) {
+        status = lbuilder.AppendNull();
+        if (status.ok()) {
+          status = rbuilder.Append(j);
+        }
+      }
+    }
+    CARROW_RETURN_IF_ERROR(status);
+  }
+
+  std::shared_ptr<arrow::Array> lindices, rindices;
+  status = lbuilder.Finish(&lindices);
+  CARROW_RETURN_IF_ERROR(status);
+  status = rbuilder.Finish(&rindices);
+  CARROW_RETURN_IF_ERROR(status);
+
+  std::vector<std::shared_ptr<arrow::Field>> fields;
+  std::vector<std::shared_ptr<arrow::Array>> arrays;
+  auto take_columns = [&fields, &arrays](std::shared_ptr<arrow::Table> table,
+                                         std::shared_ptr<arrow::Array> indices,
+                                         std::unordered_set<int> skip) {
+    for (int i = 0; i < table->num_columns(); i++) {
+      if (skip.count(i) > 0) {
+        continue;
+      }
+
+      std::shared_ptr<arrow::Array> arr, out;
+      auto status = column_array(table->column(i), &arr);
+      if (!status.ok()) {
+        return status;
+      }
+      status = take_array(arr, indices, &out);
+      if (!status.ok()) {
+        return status;
+      }
+      fields.push_back(table->field(i));
+      arrays.push_back(out);
+    }
+    return arrow::Status::OK();
+  };
+
+  if ((join_type == JOIN_SEMI) || (join_type == JOIN_ANTI)) {
+    status = take_columns(left, lindices, {});
+    CARROW_RETURN_IF_ERROR(status);
+    auto out = new Table;
+    out->ptr = arrow::Table::Make(left->schema(), arrays, lindices->length());
+    return result_t{nullptr, out};
+  }
+
+  status = take_columns(left, lindices, {});
+  CARROW_RETURN_IF_ERROR(status);
+
+  // Right keys with the same name as the left keys are merged to the left
+  // ones, taking the right value for rows that exist only in the right table
+  std::unordered_set<int> merged_keys;
+  for (size_t n = 0; n < nkeys; n++) {
+    auto name = right->field(right_keys[n])->name();
+    if (name != left->field(left_keys[n])->name()) {
+      continue;
+    }
+    merged_keys.insert(right_keys[n]);
+
+    if (join_type != JOIN_OUTER) {
+      continue;
+    }
+
+    std::shared_ptr<arrow::Array> both, out;
+    status = arrow::Concatenate({lkeys[n], rkeys[n]},
+                                arrow::default_memory_pool(), &both);
+    CARROW_RETURN_IF_ERROR(status);
+
+    arrow::Int64Builder builder;
+    auto lvalues = (arrow::Int64Array *)lindices.get();
+    auto rvalues = (arrow::Int64Array *)rindices.get();
+    for (int64_t i = 0; (i < lindices->length()) && status.ok(); i++) {
+      if (lvalues->IsValid(i)) {
+        status = builder.Append(lvalues->Value(i));
+      } else {
+        status = builder.Append(left->num_rows() + rvalues->Value(i));
+      }
+    }
+    CARROW_RETURN_IF_ERROR(status);
+    std::shared_ptr<arrow::Array> indices;
+    status = builder.Finish(&indices);
+    CARROW_RETURN_IF_ERROR(status);
+    status = take_array(both, indices, &out);
+    CARROW_RETURN_IF_ERROR(status);
+    arrays[left_keys[n]] = out;
+  }
+
+  auto nleft = fields.size();
+  status = take_columns(right, rindices, merged_keys);
+  CARROW_RETURN_IF_ERROR(status);
+
+  // Add suffixes to duplicate names
+  std::unordered_map<std::string, int> name_count;
+  for (auto &field : fields) {
+    name_count[field->name()]++;
+  }
+  for (size_t i = 0; i < fields.size(); i++) {
+    auto name = fields[i]->name();
+    if (name_count[name] > 1) {
+      name += (i < nleft) ? left_suffix : right_suffix;
+      fields[i] = fields[i]->WithName(name);
+    }
+  }
+
+  auto schema = std::make_shared<arrow::Schema>(fields);
+  auto out = new Table;
+  out->ptr = arrow::Table::Make(schema, arrays, lindices->length());
+  return result_t{nullptr, out};
+}
+
+result_t compute_boolean(void *vp, int op, void *other) {
+  auto wrapper = (Array *)other;
+  if ((op != BOOL_NOT) && (wrapper == nullptr)) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto kernel = [op, wrapper](const std::shared_ptr<arrow::Array> &values,
+                              std::shared_ptr<arrow::Array> *out) {
+    arrow::compute::FunctionContext ctx(arrow::default_memory_pool());
+    arrow::Datum datum;
+    arrow::Status status;
+    switch (op) {
+    case BOOL_AND:
+      status = arrow::compute::And(&ctx, arrow::Datum(values),
+                                   arrow::Datum(wrapper->ptr), &datum);
+      break;
+    case BOOL_OR:
+      status = arrow::compute::Or(&ctx, arrow::Datum(values),
+                                  arrow::Datum(wrapper->ptr), &datum);
+      break;
+    case BOOL_NOT:
+      status = arrow::compute::Invert(&ctx, arrow::Datum(values), &datum);
+      break;
+    default:
+      return arrow::Status::Invalid("unknown boolean operator");
+    }
+
+    if (!status.ok()) {
+      return status;
+    }
+
+    *out = datum.make_array();
+    return arrow::Status::OK();
+  };
+  return array_apply(vp, kernel);
+}
+
+result_t compute_is_valid(void *vp, int invert) {
+  auto op = [invert](const std::shared_ptr<arrow::Array> &values,
+                     std::shared_ptr<arrow::Array> *out) {
+    arrow::BooleanBuilder builder;
+    for (int64_t i = 0; i < values->length(); i++) {
+      auto status = builder.Append(values->IsValid(i) != (invert != 0));
+      if (!status.ok()) {
+        return status;
+      }
+    }
+    return builder.Finish(out);
+  };
+  return array_apply(vp, op);
+}
+
+result_t table_from_named_arrays(void *ap, char **names, size_t count) {
+  auto wrappers = (Array **)ap;
+  std::vector<std::shared_ptr<arrow::Field>> fields;
+  std::vector<std::shared_ptr<arrow::Array>> arrays;
+  for (size_t i = 0; i < count; i++) {
+    auto arr = wrappers[i]->ptr;
+    if ((i > 0) && (arr->length() != arrays[0]->length())) {
+      std::ostringstream oss;
+      oss << names[i] << ": length " << arr->length() << " != "
+          << arrays[0]->length();
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+    fields.push_back(arrow::field(names[i], arr->type()));
+    arrays.push_back(arr);
+  }
+
+  auto table = new Table;
+  table->ptr = arrow::Table::Make(arrow::schema(fields), arrays);
+  return result_t{nullptr, table};
+}
+
+typedef std::function<arrow::Status(const arrow::StringArray *, int64_t)>
+    string_op_t;
+
+// Call op on every non null value of a string array
+arrow::Status string_apply(const std::shared_ptr<arrow::Array> &values,
+                           string_op_t op,
+                           std::function<arrow::Status()> append_null) {
+  if (values->type_id() != arrow::Type::STRING) {
+    return arrow::Status::TypeError("not a string array: ",
+                                    values->type()->ToString());
+  }
+
+  auto arr = (arrow::StringArray *)values.get();
+  for (int64_t i = 0; i < arr->length(); i++) {
+    auto status = arr->IsNull(i) ? append_null() : op(arr, i);
+    if (!status.ok()) {
+      return status;
+    }
+  }
+
+  return arrow::Status::OK();
+}
+
+// Returns a new string array with every value transformed by op
+// Arrow 0.17 has no string kernels, these are implemented here
+result_t compute_str_transform(void *vp, int op, const char *chars) {
+  std::string trim_chars(chars == nullptr ? "" : chars);
+  if (trim_chars.empty()) {
+    trim_chars = " \t\n\v\f\r";
+  }
+
+  auto kernel = [op, trim_chars](const std::shared_ptr<arrow::Array> &values,
+                                 std::shared_ptr<arrow::Array> *out) {
+    arrow::StringBuilder builder;
+    auto append = [op, &trim_chars, &builder](const arrow::StringArray *arr,
+                                              int64_t i) {
+      auto value = arr->GetString(i);
+      switch (op) {
+      case STR_UPPER:
+        // ASCII only
+        std::transform(value.begin(), value.end(), value.begin(),
+                       [](unsigned char c) { return std::toupper(c); });
+        break;
+      case STR_LOWER:
+        std::transform(value.begin(), value.end(), value.begin(),
+                       [](unsigned char c) { return std::tolower(c); });
+        break;
+      case STR_TRIM: {
+        auto start = value.find_first_not_of(trim_chars);
+        if (start == std::string::npos) {
+          value.clear();
+        } else {
+          auto end = value.find_last_not_of(trim_chars);
+          value = value.substr(start, end - start + 1);
+        }
+        break;
+      }
+      default:
+        return arrow::Status::Invalid("unknown string operator");
+      }
+      return builder.Append(value);
+    };
+
+    auto append_null = [&builder]() { return builder.AppendNull(); };
+    auto status = string_apply(values, append, append_null);
+    if (!status.ok()) {
+      return status;
+    }
+    return builder.Finish(out);
+  };
+  return array_apply(vp, kernel);
+}
+
+// Number of UTF-8 characters in every value
+result_t compute_str_length(void *vp) {
+  auto kernel = [](const std::shared_ptr<arrow::Array> &values,
+                   std::shared_ptr<arrow::Array> *out) {
+    arrow::Int64Builder builder;
+    auto append = [&builder](const arrow::StringArray *arr, int64_t i) {
+      int32_t size;
+      auto data = arr->GetValue(i, &size);
+      int64_t length = 0;
+      for (int32_t j = 0; j < size; j++) {
+        // Count all bytes that are not UTF-8 continuation bytes
+        if ((data[j] & 0xC0) != 0x80) {
+          length++;
+        }
+      }
+      return builder.Append(length);
+    };
+
+    auto append_null = [&builder]() { return builder.AppendNull(); };
+    auto status = string_apply(values, append, append_null);
+    if (!status.ok()) {
+      return status;
+    }
+    return builder.Finish(out);
+  };
+  return array_apply(vp, kernel);
+}
+
+result_t compute_str_match(void *vp, int op, const char *pattern) {
+  std::string pattern_str(pattern);
+  auto kernel = [op, pattern_str](const std::shared_ptr<arrow::Array> &values,
+                             std::shared_ptr<arrow::Array> *out) {
+    arrow::BooleanBuilder builder;
+    arrow::util::string_view needle(pattern_str);
+    auto append = [op, needle, &builder](const arrow::StringArray *arr,
+                                         int64_t i) {
+      auto value = arr->GetView(i);
+      switch (op) {
+      case STR_STARTS_WITH:
+        return builder.Append(value.substr(0, needle.size()) == needle);
+      case STR_ENDS_WITH:
+        return builder.Append(
+            (value.size() >= needle.size()) &&
+            (value.substr(value.size() - needle.size()) == needle));
+      case STR_CONTAINS:
+        return builder.Append(value.find(needle) != std::string::npos);
+      }
+      return arrow::Status::Invalid("unknown match operator");
+    };
+
+    auto append_null = [&builder]() { return builder.AppendNull(); };
+    auto status = string_apply(values, append, append_null);
+    if (!status.ok()) {
+      return status;
+    }
+    return builder.Finish(out);
+  };
+  return array_apply(vp, kernel);
+}
+
+// Returns a list<string> array, max_splits < 0 means no limit
+result_t compute_str_split(void *vp, const char *sep, int64_t max_splits) {
+  std::string separator(sep);
+  if (separator.empty()) {
+    return result_t{strdup("empty separator"), nullptr};
+  }
+
+  auto kernel = [separator,
+                 max_splits](const std::shared_ptr<arrow::Array> &values,
+                             std::shared_ptr<arrow::Array> *out) {
+    auto value_builder = std::make_shared<arrow::StringBuilder>();
+    arrow::ListBuilder builder(arrow::default_memory_pool(), value_builder);
+    auto append = [&separator, max_splits, &builder,
+                   &value_builder](const arrow::StringArray *arr, int64_t i) {
+      auto status = builder.Append();
+      if (!status.ok()) {
+        return status;
+      }
+
+      auto value = arr->GetView(i);
+      size_t start = 0;
+      for (int64_t n = 0; (max_splits < 0) || (n < max_splits); n++) {
+        auto end = value.find(separator, start);
+        if (end == std::string::npos) {
+          break;
+        }
+        status = value_builder->Append(value.substr(start, end - start));
+        if (!status.ok()) {
+          return status;
+        }
+        start = end + separator.size();
+      }
+      return value_builder->Append(value.substr(start));
+    };
+
+    auto append_null = [&builder]() { return builder.AppendNull(); };
+    auto status = string_apply(values, append, append_null);
+    if (!status.ok()) {
+      return status;
+    }
+    return builder.Finish(out);
+  };
+  return array_apply(vp, kernel);
+}
+
+// Parse values with strptime(3) format to timestamps (UTC)
+// %z offsets are applied, zone names (%Z) can't be resolved and are an error
+result_t compute_strptime(void *vp, const char *format) {
+  std::string fmt(format);
+  for (size_t i = 0; i + 1 < fmt.size(); i++) {
+    if (fmt[i] != '%') {
+      continue;
+    }
+    i++; // Skip directive, "%%" included
+    if (fmt[i] == 'Z') {
+      return result_t{strdup("%Z (time zone name) is not supported, use %z"),
+                      nullptr};
+    }
+  }
+
+  auto kernel = [fmt](const std::shared_ptr<arrow::Array> &values,
+                      std::shared_ptr<arrow::Array> *out) {
+    arrow::TimestampBuilder builder(data_type(TIMESTAMP_DTYPE),
+                                    arrow::default_memory_pool());
+    auto append = [&fmt, &builder](const arrow::StringArray *arr, int64_t i) {
+      auto value = arr->GetString(i);
+      struct tm tm = {};
+      auto end = strptime(value.c_str(), fmt.c_str(), &tm);
+      if ((end == nullptr) || (*end != '\0')) {
+        return arrow::Status::Invalid("row ", i, ": can't parse \"", value,
+                                      "\" with \"", fmt, "\"");
+      }
+      // timegm ignores the %z offset (tm_gmtoff, 0 if not parsed)
+      int64_t secs = timegm(&tm) - tm.tm_gmtoff;
+      return builder.Append(secs * 1000000000LL);
+    };
+
+    auto append_null = [&builder]() { return builder.AppendNull(); };
+    auto status = string_apply(values, append, append_null);
+    if (!status.ok()) {
+      return status;
+    }
+    return builder.Finish(out);
+  };
+  return array_apply(vp, kernel);
+}
+
+// Returns the values of list array at row i
+result_t array_list_at(void *vp, int64_t i) {
+  auto op = [i](const std::shared_ptr<arrow::Array> &values,
+                std::shared_ptr<arrow::Array> *out) {
+    if (values->type_id() != arrow::Type::LIST) {
+      return arrow::Status::TypeError("not a list array");
+    }
+
+    auto arr = (arrow::ListArray *)values.get();
+    if ((i < 0) || (i >= arr->length())) {
+      return arrow::Status::IndexError("list index out of range");
+    }
+
+    *out = arr->value_slice(i);
+    return arrow::Status::OK();
+  };
+  return array_apply(vp, op);
+}
+
 void *meta_new() {
   auto meta = new Metadata;
   meta->ptr = std::make_shared<arrow::KeyValueMetadata>();
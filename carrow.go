@@ -77,6 +77,17 @@ func NewSchema(fields []*Field) (*Schema, error) {
 	return schema, nil
 }
 
+// NewSchemaFromPtr creates a new schema from underlying C pointer
+// You probably shouldn't use this function
+func NewSchemaFromPtr(ptr unsafe.Pointer) *Schema {
+	return &Schema{ptr}
+}
+
+// Ptr returns the underlying C++ pointer
+func (s *Schema) Ptr() unsafe.Pointer {
+	return s.ptr
+}
+
 // Metadata returns the schema metadata
 func (s *Schema) Metadata() (*Metadata, error) {
 	r := C.schema_meta(s.ptr)
@@ -115,6 +126,16 @@ func errFromResult(r C.result_t) error {
 	return err
 }
 
+// AppendNull appends a null value
+func (b *builder) AppendNull() error {
+	if err := b.fl.flush(); err != nil {
+		return err
+	}
+
+	r := C.array_builder_append_null(b.ptr)
+	return errFromResult(r)
+}
+
 // Finish returns array from builder
 // You can't use the builder after calling Finish
 func (b *builder) Finish() (*Array, error) {
@@ -146,6 +167,10 @@ func (b *BoolArrayBuilder) Append(val bool) error {
 }
 
 func (b *BoolArrayBuilder) flush() error {
+	if b.bufferIdx == 0 {
+		return nil
+	}
+
 	cSize := C.long(b.bufferIdx)
 	b.bufferIdx = 0
 	r := C.array_builder_append_bools(b.ptr, (*C.uint8_t)(&b.buffer[0]), cSize)
@@ -164,6 +189,10 @@ func (b *Float64ArrayBuilder) Append(val float64) error {
 }
 
 func (b *Float64ArrayBuilder) flush() error {
+	if b.bufferIdx == 0 {
+		return nil
+	}
+
 	cSize := C.long(b.bufferIdx)
 	b.bufferIdx = 0
 	r := C.array_builder_append_floats(b.ptr, (*C.double)(&b.buffer[0]), cSize)
@@ -182,6 +211,10 @@ func (b *Integer64ArrayBuilder) Append(val int64) error {
 }
 
 func (b *Integer64ArrayBuilder) flush() error {
+	if b.bufferIdx == 0 {
+		return nil
+	}
+
 	cSize := C.long(b.bufferIdx)
 	b.bufferIdx = 0
 	r := C.array_builder_append_ints(b.ptr, (*C.long)(&b.buffer[0]), cSize)
@@ -211,11 +244,16 @@ func (b *StringArrayBuilder) Append(val string) error {
 }
 
 func (b *StringArrayBuilder) flush() error {
+	if b.bufferIdx == 0 {
+		return nil
+	}
+
 	cSize := C.long(b.bufferIdx)
 	b.bufferIdx = 0
 	r := C.array_builder_append_strings(b.ptr, (**C.char)(&b.buffer[0]), cSize)
-	for _, cp := range b.buffer {
-		C.free(unsafe.Pointer(cp))
+	for i := 0; i < int(cSize); i++ {
+		C.free(unsafe.Pointer(b.buffer[i]))
+		b.buffer[i] = nil
 	}
 	return errFromResult(r)
 }
@@ -232,6 +270,10 @@ func (b *TimestampArrayBuilder) Append(val time.Time) error {
 }
 
 func (b *TimestampArrayBuilder) flush() error {
+	if b.bufferIdx == 0 {
+		return nil
+	}
+
 	cSize := C.long(b.bufferIdx)
 	b.bufferIdx = 0
 	r := C.array_builder_append_timestamps(b.ptr, (*C.long)(&b.buffer[0]), cSize)
@@ -248,13 +290,17 @@ func (a *Array) DType() DType {
 	return DType(C.array_dtype(a.ptr))
 }
 
-
 // Length returns the length of the array
 func (a *Array) Length() int {
 	i := C.array_length(a.ptr)
 	return int(i)
 }
 
+// IsNull returns true if value at location is null
+func (a *Array) IsNull(i int) bool {
+	return C.array_is_null(a.ptr, C.longlong(i)) == 1
+}
+
 // BoolAt returns bool at location
 func (a *Array) BoolAt(i int) (bool, error) {
 	val := C.array_bool_at(a.ptr, C.longlong(i))
@@ -293,11 +339,185 @@ func (a *Array) StringAt(i int) (string, error) {
 	return s, nil
 }
 
-// TimeAt returns time at location
+// TimeAt returns time at location, timestamps in any unit are supported
 func (a *Array) TimeAt(i int) (time.Time, error) {
 	epochNano := int64(C.array_timestamp_at(a.ptr, C.longlong(i)))
-	t := time.Unix(epochNano/1e9, epochNano%1e9)
-	return t, nil
+	return timeFromNanos(epochNano), nil
+}
+
+// timeFromNanos converts timestamps from C (always in nanoseconds) to time
+func timeFromNanos(epochNano int64) time.Time {
+	return time.Unix(epochNano/1e9, epochNano%1e9)
+}
+
+// ListAt returns the values of a ListType array at location
+func (a *Array) ListAt(i int) (*Array, error) {
+	r := C.array_list_at(a.ptr, C.int64_t(i))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// StructField returns the ith child array of a StructType array
+func (a *Array) StructField(i int) (*Array, error) {
+	r := C.array_struct_field(a.ptr, C.int(i))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// Slice returns a 0 copy slice of a
+// If length is -1 will return until end of array
+func (a *Array) Slice(offset int, length int) (*Array, error) {
+	length, err := sliceLength(offset, length, a.Length())
+	if err != nil {
+		return nil, err
+	}
+
+	r := C.array_slice(a.ptr, C.int64_t(offset), C.int64_t(length))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// Equal returns true if a and other have the same type and values
+func (a *Array) Equal(other *Array) (bool, error) {
+	r := C.array_equal(a.ptr, other.ptr)
+	if err := errFromResult(r); err != nil {
+		return false, err
+	}
+
+	return r.i == 1, nil
+}
+
+// ApproxEqual is like Equal but floating point values may differ by up to
+// epsilon
+func (a *Array) ApproxEqual(other *Array, epsilon float64) (bool, error) {
+	r := C.array_approx_equal(a.ptr, other.ptr, C.double(epsilon))
+	if err := errFromResult(r); err != nil {
+		return false, err
+	}
+
+	return r.i == 1, nil
+}
+
+// Concatenate returns a new array with values of arrays one after the other
+// All arrays must be of the same type
+func Concatenate(arrays ...*Array) (*Array, error) {
+	if len(arrays) == 0 {
+		return nil, fmt.Errorf("no arrays to concatenate")
+	}
+
+	arrs := make([]unsafe.Pointer, 0, len(arrays))
+	for _, arr := range arrays {
+		arrs = append(arrs, arr.ptr)
+	}
+	aptr := (unsafe.Pointer)(&arrs[0])
+	r := C.array_concatenate(aptr, C.size_t(len(arrs)))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// ChunkedArray is a column made of several arrays (chunks) of the same type
+type ChunkedArray struct {
+	ptr unsafe.Pointer
+}
+
+func newChunkedArray(ptr unsafe.Pointer) *ChunkedArray {
+	chunked := &ChunkedArray{ptr}
+	runtime.SetFinalizer(chunked, func(c *ChunkedArray) {
+		C.chunked_array_free(c.ptr)
+	})
+
+	return chunked
+}
+
+// DType returns the chunked array data type
+func (c *ChunkedArray) DType() DType {
+	return DType(C.chunked_array_dtype(c.ptr))
+}
+
+// Length returns the total length of all chunks
+func (c *ChunkedArray) Length() int {
+	return int(C.chunked_array_length(c.ptr))
+}
+
+// NumChunks returns the number of chunks
+func (c *ChunkedArray) NumChunks() int {
+	return int(C.chunked_array_num_chunks(c.ptr))
+}
+
+// Chunk returns the nth chunk
+func (c *ChunkedArray) Chunk(i int) (*Array, error) {
+	ptr := C.chunked_array_chunk(c.ptr, C.int(i))
+	if ptr == nil {
+		return nil, fmt.Errorf("can't find chunk %d", i)
+	}
+
+	return &Array{ptr}, nil
+}
+
+// Array returns all chunks concatenated to a single array
+func (c *ChunkedArray) Array() (*Array, error) {
+	r := C.chunked_array_combine(c.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// Slice returns a 0 copy slice of c
+// If length is -1 will return until end of array
+func (c *ChunkedArray) Slice(offset int, length int) (*ChunkedArray, error) {
+	length, err := sliceLength(offset, length, c.Length())
+	if err != nil {
+		return nil, err
+	}
+
+	r := C.chunked_array_slice(c.ptr, C.int64_t(offset), C.int64_t(length))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return newChunkedArray(r.ptr), nil
+}
+
+// sliceLength validates slice bounds, returns the slice length (resolving -1)
+func sliceLength(offset, length, size int) (int, error) {
+	if offset < 0 || offset > size {
+		return 0, fmt.Errorf("offset %d out of range [0:%d]", offset, size)
+	}
+
+	if length == -1 {
+		length = size - offset
+	}
+
+	if length < 0 || offset+length > size {
+		return 0, fmt.Errorf("slice [%d:%d] out of range [0:%d]", offset, offset+length, size)
+	}
+
+	return length, nil
+}
+
+// Equal returns true if c and other have the same type and values, regardless
+// of chunk layout
+func (c *ChunkedArray) Equal(other *ChunkedArray) (bool, error) {
+	r := C.chunked_array_equal(c.ptr, other.ptr)
+	if err := errFromResult(r); err != nil {
+		return false, err
+	}
+
+	return r.i == 1, nil
 }
 
 // Table is arrow table
@@ -353,6 +573,8 @@ func (t *Table) Schema() *Schema {
 }
 
 // Column returns the nth column (Array)
+// If the column has several chunks they are concatenated, use ChunkedColumn
+// to avoid the copy
 func (t *Table) Column(i int) (*Array, error) {
 	ptr := C.table_column(t.ptr, C.int(i))
 	if ptr == nil {
@@ -362,19 +584,24 @@ func (t *Table) Column(i int) (*Array, error) {
 	return &Array{ptr}, nil
 }
 
+// ChunkedColumn returns the nth column as is
+func (t *Table) ChunkedColumn(i int) (*ChunkedArray, error) {
+	ptr := C.table_chunked_column(t.ptr, C.int(i))
+	if ptr == nil {
+		return nil, fmt.Errorf("can't find column %d", i)
+	}
+
+	return newChunkedArray(ptr), nil
+}
+
 // ColumnByName returns column by name
 func (t *Table) ColumnByName(name string) (*Array, error) {
-	for i := 0; i < t.NumCols(); i++ {
-		fld, err := t.Field(i)
-		if err != nil {
-			return nil, err
-		}
-		if fld.Name() == name {
-			return t.Column(i)
-		}
+	i, err := t.columnIndex(name)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("column %q not found", name)
+	return t.Column(i)
 }
 
 // ColumnNames names returns names of columns
@@ -393,13 +620,182 @@ func (t *Table) ColumnNames() ([]string, error) {
 
 // Slice returns a 0 copy slize of t
 // If length is -1 will return until end of table
-func (t *Table) Slice(offset int, length int) *Table {
-	if length == -1 {
-		length = t.NumRows()
+func (t *Table) Slice(offset int, length int) (*Table, error) {
+	length, err := sliceLength(offset, length, t.NumRows())
+	if err != nil {
+		return nil, err
 	}
 
 	ptr := C.table_slice(t.ptr, C.int64_t(offset), C.int64_t(length))
-	return &Table{ptr}
+	return &Table{ptr}, nil
+}
+
+// AddColumn returns a new table with arr inserted as column i
+func (t *Table) AddColumn(i int, field *Field, arr *Array) (*Table, error) {
+	r := C.table_add_column(t.ptr, C.int(i), field.ptr, arr.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
+
+// RemoveColumn returns a new table without column i
+func (t *Table) RemoveColumn(i int) (*Table, error) {
+	r := C.table_remove_column(t.ptr, C.int(i))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
+
+// SetColumn returns a new table with column i replaced by arr
+func (t *Table) SetColumn(i int, field *Field, arr *Array) (*Table, error) {
+	r := C.table_set_column(t.ptr, C.int(i), field.ptr, arr.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
+
+// SelectColumns returns a new table with only the named columns, in the order
+// given
+func (t *Table) SelectColumns(names ...string) (*Table, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no columns selected")
+	}
+
+	indices := make([]C.int, 0, len(names))
+	for _, name := range names {
+		i, err := t.columnIndex(name)
+		if err != nil {
+			return nil, err
+		}
+		indices = append(indices, C.int(i))
+	}
+
+	r := C.table_select_columns(t.ptr, &indices[0], C.size_t(len(indices)))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
+
+// RenameColumns returns a new table with columns renamed by names (old -> new)
+// Columns not in names keep their name
+func (t *Table) RenameColumns(names map[string]string) (*Table, error) {
+	current, err := t.ColumnNames()
+	if err != nil {
+		return nil, err
+	}
+
+	for old := range names {
+		if _, err := t.columnIndex(old); err != nil {
+			return nil, err
+		}
+	}
+
+	cNames := make([]*C.char, 0, len(current))
+	for _, name := range current {
+		if newName, ok := names[name]; ok {
+			name = newName
+		}
+		cNames = append(cNames, C.CString(name))
+	}
+	defer func() {
+		for _, cp := range cNames {
+			C.free(unsafe.Pointer(cp))
+		}
+	}()
+
+	if len(cNames) == 0 {
+		return t, nil
+	}
+
+	r := C.table_rename_columns(t.ptr, &cNames[0], C.size_t(len(cNames)))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
+
+// CombineChunks returns a new table where every column has a single chunk
+func (t *Table) CombineChunks() (*Table, error) {
+	r := C.table_combine_chunks(t.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
+
+// Equal returns true if t and other have the same schema and values,
+// regardless of chunk layout
+func (t *Table) Equal(other *Table) (bool, error) {
+	r := C.table_equal(t.ptr, other.ptr)
+	if err := errFromResult(r); err != nil {
+		return false, err
+	}
+
+	return r.i == 1, nil
+}
+
+// ConcatenateOptions are options for ConcatenateTablesWithOptions
+type ConcatenateOptions struct {
+	// UnifySchemas allows tables with different (but compatible) schemas,
+	// missing columns are filled with nulls
+	UnifySchemas bool
+}
+
+// ConcatenateTables returns a new table with rows of tables one after the
+// other
+// All tables must have the same schema
+func ConcatenateTables(tables ...*Table) (*Table, error) {
+	return ConcatenateTablesWithOptions(ConcatenateOptions{}, tables...)
+}
+
+// ConcatenateTablesWithOptions is ConcatenateTables with options
+func ConcatenateTablesWithOptions(opts ConcatenateOptions, tables ...*Table) (*Table, error) {
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no tables to concatenate")
+	}
+
+	ptrs := make([]unsafe.Pointer, 0, len(tables))
+	for _, t := range tables {
+		ptrs = append(ptrs, t.ptr)
+	}
+
+	unify := 0
+	if opts.UnifySchemas {
+		unify = 1
+	}
+
+	tptr := (unsafe.Pointer)(&ptrs[0])
+	r := C.table_concatenate(tptr, C.size_t(len(ptrs)), C.int(unify))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
+
+// columnIndex returns the index of column name
+func (t *Table) columnIndex(name string) (int, error) {
+	for i := 0; i < t.NumCols(); i++ {
+		fld, err := t.Field(i)
+		if err != nil {
+			return -1, err
+		}
+		if fld.Name() == name {
+			return i, nil
+		}
+	}
+
+	return -1, fmt.Errorf("column %q not found", name)
 }
 
 // Field returns the nth field
@@ -13,6 +13,9 @@ extern const int FLOAT64_DTYPE;
 extern const int INTEGER64_DTYPE;
 extern const int STRING_DTYPE;
 extern const int TIMESTAMP_DTYPE;
+extern const int DICTIONARY_DTYPE;
+extern const int LIST_DTYPE;
+extern const int STRUCT_DTYPE;
 
 typedef struct {
   const char *err;
@@ -20,6 +23,60 @@ typedef struct {
   int64_t i;
 } result_t;
 
+// dtype is -1 for null scalar, s is used only for string input scalars
+typedef struct {
+  const char *err;
+  int dtype;
+  int64_t i;
+  double f;
+  const char *s;
+} scalar_t;
+
+// Kind of data passed to compute functions
+#define ARRAY_DATUM 0
+#define CHUNKED_ARRAY_DATUM 1
+#define TABLE_DATUM 2
+
+#define COUNT_VALID 0
+#define COUNT_NULLS 1
+
+#define CMP_EQUAL 0
+#define CMP_NOT_EQUAL 1
+#define CMP_LESS 2
+#define CMP_LESS_EQUAL 3
+#define CMP_GREATER 4
+#define CMP_GREATER_EQUAL 5
+
+#define ARITH_ADD 0
+#define ARITH_SUBTRACT 1
+#define ARITH_MULTIPLY 2
+#define ARITH_DIVIDE 3
+
+#define AGG_SUM 0
+#define AGG_MEAN 1
+#define AGG_MIN 2
+#define AGG_MAX 3
+#define AGG_COUNT 4
+#define AGG_COUNT_DISTINCT 5
+
+#define BOOL_AND 0
+#define BOOL_OR 1
+#define BOOL_NOT 2
+
+#define JOIN_INNER 0
+#define JOIN_LEFT 1
+#define JOIN_OUTER 2
+#define JOIN_SEMI 3
+#define JOIN_ANTI 4
+
+#define STR_UPPER 0
+#define STR_LOWER 1
+#define STR_TRIM 2
+
+#define STR_STARTS_WITH 0
+#define STR_ENDS_WITH 1
+#define STR_CONTAINS 2
+
 void *field_new(char *name, int type);
 const char *field_name(void *field);
 int field_dtype(void *vp);
@@ -28,6 +85,7 @@ void field_free(void *vp);
 void *schema_new(void *vp, size_t count);
 result_t schema_meta(void *vp);
 result_t schema_set_meta(void *vp, void *meta);
+result_t schema_to_string(void *vp);
 void schema_free(void *vp);
 
 result_t array_builder_new(int dtype);
@@ -43,6 +101,7 @@ result_t array_builder_append_timestamp(void *vp, long value);
 result_t array_builder_append_timestamps(void *vp, long *values,
                                          int64_t length);
 
+result_t array_builder_append_null(void *vp);
 result_t array_builder_finish(void *vp);
 
 int64_t array_length(void *vp);
@@ -52,17 +111,106 @@ double array_float_at(void *vp, long long i);
 const char *array_str_at(void *vp, long long i);
 int64_t array_timestamp_at(void *vp, long long i);
 int array_dtype(void *vp);
+int array_is_null(void *vp, long long i);
+result_t array_to_string(void *vp);
+
+result_t array_slice(void *vp, int64_t offset, int64_t length);
+result_t array_concatenate(void *vp, size_t count);
+result_t array_equal(void *vp, void *op);
+result_t array_approx_equal(void *vp, void *op, double epsilon);
 
 void array_free(void *vp);
 
+int chunked_array_dtype(void *vp);
+int64_t chunked_array_length(void *vp);
+int chunked_array_num_chunks(void *vp);
+void *chunked_array_chunk(void *vp, int i);
+result_t chunked_array_combine(void *vp);
+result_t chunked_array_slice(void *vp, int64_t offset, int64_t length);
+result_t chunked_array_equal(void *vp, void *op);
+result_t chunked_array_to_string(void *vp);
+void chunked_array_free(void *vp);
+
 void *table_new(void *sp, void *ap, size_t ncols);
 void table_free(void *vp);
 long long table_num_cols(void *vp);
 long long table_num_rows(void *vp);
 void *table_schema(void *vp);
 void *table_column(void *vp, int i);
+void *table_chunked_column(void *vp, int i);
 void *table_field(void *vp, int i);
 void *table_slice(void *vp, int64_t offset, int64_t length);
+result_t table_add_column(void *vp, int i, void *fp, void *ap);
+result_t table_remove_column(void *vp, int i);
+result_t table_set_column(void *vp, int i, void *fp, void *ap);
+result_t table_select_columns(void *vp, int *indices, size_t count);
+result_t table_rename_columns(void *vp, char **names, size_t count);
+result_t table_concatenate(void *vp, size_t count, int unify_schemas);
+result_t table_combine_chunks(void *vp);
+result_t table_equal(void *vp, void *op);
+result_t table_to_string(void *vp);
+result_t table_from_batches(void *vp, size_t count);
+result_t table_batch_reader(void *vp, int64_t max_chunksize);
+result_t batch_reader_next(void *vp);
+void batch_reader_free(void *vp);
+
+long long record_batch_num_cols(void *vp);
+long long record_batch_num_rows(void *vp);
+void *record_batch_schema(void *vp);
+void *record_batch_column(void *vp, int i);
+void *record_batch_field(void *vp, int i);
+void *record_batch_slice(void *vp, int64_t offset, int64_t length);
+void record_batch_free(void *vp);
+
+scalar_t compute_sum(void *vp, int kind);
+scalar_t compute_mean(void *vp, int kind);
+scalar_t compute_min_max(void *vp, int kind, int skip_nulls, scalar_t *max);
+scalar_t compute_count(void *vp, int kind, int mode);
+scalar_t compute_variance(void *vp, int kind, int ddof);
+scalar_t compute_stddev(void *vp, int kind, int ddof);
+
+result_t compute_filter(void *vp, int kind, void *mp, int emit_nulls);
+result_t compute_take(void *vp, int kind, void *ip, int drop_null_indices);
+
+// Right hand side is either op_array or scalar (when op_array is NULL)
+result_t compute_compare(void *vp, int op, void *op_array, scalar_t *scalar);
+result_t compute_arithmetic(void *vp, int op, void *op_array,
+                            scalar_t *scalar);
+
+result_t compute_sort_indices(void *vp);
+result_t table_sort_by(void *vp, int *columns, int *descending,
+                       int *nulls_first, size_t count);
+
+result_t compute_cast(void *vp, int dtype, int safe);
+result_t table_cast_columns(void *vp, int *columns, int *dtypes, size_t count,
+                            int safe);
+
+result_t compute_unique(void *vp);
+result_t compute_value_counts(void *vp);
+result_t compute_is_in(void *vp, void *values);
+result_t compute_dictionary_encode(void *vp);
+result_t array_dictionary(void *vp);
+result_t array_dictionary_indices(void *vp);
+result_t array_struct_field(void *vp, int i);
+
+result_t table_group_by(void *vp, int *keys, size_t nkeys, int *columns,
+                        int *funcs, char **names, size_t naggs);
+
+result_t table_join(void *lp, void *rp, int *left_keys, int *right_keys,
+                    size_t nkeys, int join_type, const char *left_suffix,
+                    const char *right_suffix);
+
+// other is ignored for BOOL_NOT
+result_t compute_boolean(void *vp, int op, void *other);
+result_t compute_is_valid(void *vp, int invert);
+result_t table_from_named_arrays(void *ap, char **names, size_t count);
+
+result_t compute_str_transform(void *vp, int op, const char *chars);
+result_t compute_str_length(void *vp);
+result_t compute_str_match(void *vp, int op, const char *pattern);
+result_t compute_str_split(void *vp, const char *sep, int64_t max_splits);
+result_t compute_strptime(void *vp, const char *format);
+result_t array_list_at(void *vp, int64_t i);
 
 void *meta_new();
 result_t meta_set(void *vp, const char *key, const char *value);
@@ -90,11 +90,128 @@ func TestTable(t *testing.T) {
 	require.Equal(Float64Type, arr.DType(), "float dtype")
 
 	offset, length := 10, 37
-	s := table.Slice(offset, length)
+	s, err := table.Slice(offset, length)
+	require.NoError(err, "slice")
 	names, err = s.ColumnNames()
 	require.NoError(err, "ColumnNames")
 	require.Equal([]string{intColName, floatColName}, names, "slice column names")
 	require.Equal(length, s.NumRows(), "slice rows")
+
+	s, err = table.Slice(offset, -1)
+	require.NoError(err, "slice to end")
+	require.Equal(table.NumRows()-offset, s.NumRows(), "slice to end rows")
+	_, err = table.Slice(table.NumRows()+1, 0)
+	require.Error(err, "offset out of range")
+	_, err = table.Slice(offset, table.NumRows())
+	require.Error(err, "length out of range")
+
+	chunked, err := table.ChunkedColumn(0)
+	require.NoError(err, "ChunkedColumn(0)")
+	require.Equal(Integer64Type, chunked.DType(), "chunked dtype")
+	require.Equal(nrows, chunked.Length(), "chunked length")
+	require.Equal(1, chunked.NumChunks(), "num chunks")
+
+	cs, err := chunked.Slice(offset, -1)
+	require.NoError(err, "chunked slice")
+	require.Equal(nrows-offset, cs.Length(), "chunked slice length")
+	_, err = chunked.Slice(nrows+1, 0)
+	require.Error(err, "chunked slice out of range")
+}
+
+func TestTableColumns(t *testing.T) {
+	require := require.New(t)
+	nrows := 20
+	table := buildTable(require, nrows)
+
+	arr, err := table.Column(0)
+	require.NoError(err, "Column(0)")
+	field, err := NewField("copy", Integer64Type)
+	require.NoError(err, "field")
+
+	added, err := table.AddColumn(2, field, arr)
+	require.NoError(err, "AddColumn")
+	names, err := added.ColumnNames()
+	require.NoError(err, "ColumnNames")
+	require.Equal([]string{intColName, floatColName, "copy"}, names, "added names")
+
+	removed, err := added.RemoveColumn(0)
+	require.NoError(err, "RemoveColumn")
+	names, err = removed.ColumnNames()
+	require.NoError(err, "ColumnNames")
+	require.Equal([]string{floatColName, "copy"}, names, "removed names")
+
+	set, err := table.SetColumn(1, field, arr)
+	require.NoError(err, "SetColumn")
+	names, err = set.ColumnNames()
+	require.NoError(err, "ColumnNames")
+	require.Equal([]string{intColName, "copy"}, names, "set names")
+
+	selected, err := table.SelectColumns(floatColName, intColName)
+	require.NoError(err, "SelectColumns")
+	names, err = selected.ColumnNames()
+	require.NoError(err, "ColumnNames")
+	require.Equal([]string{floatColName, intColName}, names, "selected names")
+	require.Equal(nrows, selected.NumRows(), "selected rows")
+
+	_, err = table.SelectColumns("no-such-column")
+	require.Error(err, "select unknown column")
+
+	renamed, err := table.RenameColumns(map[string]string{intColName: "i"})
+	require.NoError(err, "RenameColumns")
+	names, err = renamed.ColumnNames()
+	require.NoError(err, "ColumnNames")
+	require.Equal([]string{"i", floatColName}, names, "renamed names")
+}
+
+func TestConcatenateTables(t *testing.T) {
+	require := require.New(t)
+	t1 := buildTable(require, 10)
+	t2 := buildTable(require, 7)
+
+	table, err := ConcatenateTables(t1, t2)
+	require.NoError(err, "concatenate")
+	require.Equal(17, table.NumRows(), "rows")
+
+	chunked, err := table.ChunkedColumn(0)
+	require.NoError(err, "ChunkedColumn(0)")
+	require.Equal(2, chunked.NumChunks(), "chunks")
+
+	arr, err := table.Column(0)
+	require.NoError(err, "Column(0)")
+	require.Equal(17, arr.Length(), "column length")
+
+	combined, err := table.CombineChunks()
+	require.NoError(err, "combine chunks")
+	chunked, err = combined.ChunkedColumn(0)
+	require.NoError(err, "ChunkedColumn(0)")
+	require.Equal(1, chunked.NumChunks(), "combined chunks")
+
+	ok, err := combined.Equal(table)
+	require.NoError(err, "equal")
+	require.True(ok, "combined equal")
+
+	ok, err = t1.Equal(t2)
+	require.NoError(err, "equal")
+	require.False(ok, "different tables")
+
+	fld, err := NewField("other", StringType)
+	require.NoError(err, "field")
+	schema, err := NewSchema([]*Field{fld})
+	require.NoError(err, "schema")
+	sb := NewStringArrayBuilder()
+	require.NoError(sb.Append("hello"), "append")
+	sarr, err := sb.Finish()
+	require.NoError(err, "finish")
+	t3, err := NewTableFromArrays(schema, []*Array{sarr})
+	require.NoError(err, "table")
+
+	_, err = ConcatenateTables(t1, t3)
+	require.Error(err, "different schemas")
+
+	table, err = ConcatenateTablesWithOptions(ConcatenateOptions{UnifySchemas: true}, t1, t3)
+	require.NoError(err, "unify schemas")
+	require.Equal(3, table.NumCols(), "unified columns")
+	require.Equal(11, table.NumRows(), "unified rows")
 }
 
 func buildTable(require *require.Assertions, nrows int) *Table {
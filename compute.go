@@ -0,0 +1,645 @@
+package carrow
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+/*
+#include "carrow.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// CountMode is which values Count counts
+type CountMode int
+
+// Count modes
+const (
+	CountValid CountMode = C.COUNT_VALID // non-null values
+	CountNulls CountMode = C.COUNT_NULLS // null values
+)
+
+// valueFromScalar converts a C scalar to Go value
+// Returned value is nil for null, otherwise bool, int64, float64 or time.Time
+// depending on the scalar type
+func valueFromScalar(s C.scalar_t) (interface{}, error) {
+	if s.err != nil {
+		err := fmt.Errorf(C.GoString(s.err))
+		C.free(unsafe.Pointer(s.err))
+		return nil, err
+	}
+
+	switch DType(s.dtype) {
+	case -1:
+		return nil, nil
+	case BoolType:
+		return s.i != 0, nil
+	case Integer64Type:
+		return int64(s.i), nil
+	case Float64Type:
+		return float64(s.f), nil
+	case TimestampType:
+		return timeFromNanos(int64(s.i)), nil
+	}
+
+	return nil, fmt.Errorf("unknown scalar type: %d", s.dtype)
+}
+
+// floatFromScalar is valueFromScalar for float results, null is returned as
+// an error
+func floatFromScalar(s C.scalar_t) (float64, error) {
+	val, err := valueFromScalar(s)
+	if err != nil {
+		return 0, err
+	}
+
+	switch v := val.(type) {
+	case float64:
+		return v, nil
+	case nil:
+		return 0, fmt.Errorf("no values")
+	}
+
+	return 0, fmt.Errorf("unexpected result type: %T", val)
+}
+
+func intFromScalar(s C.scalar_t) (int, error) {
+	val, err := valueFromScalar(s)
+	if err != nil {
+		return 0, err
+	}
+
+	v, ok := val.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected result type: %T", val)
+	}
+
+	return int(v), nil
+}
+
+// Sum returns the sum of non-null values in a
+// The result is int64 for integer arrays and float64 for float arrays
+func (a *Array) Sum() (interface{}, error) {
+	return valueFromScalar(C.compute_sum(a.ptr, C.ARRAY_DATUM))
+}
+
+// Mean returns the mean of non-null values in a
+func (a *Array) Mean() (float64, error) {
+	return floatFromScalar(C.compute_mean(a.ptr, C.ARRAY_DATUM))
+}
+
+// MinMax returns the minimal and maximal values in a
+// If skipNulls is false and a has nulls, the returned values are nil
+func (a *Array) MinMax(skipNulls bool) (interface{}, interface{}, error) {
+	return minMax(a.ptr, C.ARRAY_DATUM, skipNulls)
+}
+
+// Count returns the number of values in a, according to mode
+func (a *Array) Count(mode CountMode) (int, error) {
+	return intFromScalar(C.compute_count(a.ptr, C.ARRAY_DATUM, C.int(mode)))
+}
+
+// Variance returns the variance of non-null values in a
+// ddof is delta degrees of freedom (0 for population, 1 for sample variance)
+func (a *Array) Variance(ddof int) (float64, error) {
+	return floatFromScalar(C.compute_variance(a.ptr, C.ARRAY_DATUM, C.int(ddof)))
+}
+
+// Stddev returns the standard deviation of non-null values in a
+// ddof is delta degrees of freedom (0 for population, 1 for sample)
+func (a *Array) Stddev(ddof int) (float64, error) {
+	return floatFromScalar(C.compute_stddev(a.ptr, C.ARRAY_DATUM, C.int(ddof)))
+}
+
+// Sum returns the sum of non-null values in c
+// The result is int64 for integer arrays and float64 for float arrays
+func (c *ChunkedArray) Sum() (interface{}, error) {
+	return valueFromScalar(C.compute_sum(c.ptr, C.CHUNKED_ARRAY_DATUM))
+}
+
+// Mean returns the mean of non-null values in c
+func (c *ChunkedArray) Mean() (float64, error) {
+	return floatFromScalar(C.compute_mean(c.ptr, C.CHUNKED_ARRAY_DATUM))
+}
+
+// MinMax returns the minimal and maximal values in c
+// If skipNulls is false and c has nulls, the returned values are nil
+func (c *ChunkedArray) MinMax(skipNulls bool) (interface{}, interface{}, error) {
+	return minMax(c.ptr, C.CHUNKED_ARRAY_DATUM, skipNulls)
+}
+
+// Count returns the number of values in c, according to mode
+func (c *ChunkedArray) Count(mode CountMode) (int, error) {
+	return intFromScalar(C.compute_count(c.ptr, C.CHUNKED_ARRAY_DATUM, C.int(mode)))
+}
+
+// Variance returns the variance of non-null values in c
+// ddof is delta degrees of freedom (0 for population, 1 for sample variance)
+func (c *ChunkedArray) Variance(ddof int) (float64, error) {
+	return floatFromScalar(C.compute_variance(c.ptr, C.CHUNKED_ARRAY_DATUM, C.int(ddof)))
+}
+
+// Stddev returns the standard deviation of non-null values in c
+// ddof is delta degrees of freedom (0 for population, 1 for sample)
+func (c *ChunkedArray) Stddev(ddof int) (float64, error) {
+	return floatFromScalar(C.compute_stddev(c.ptr, C.CHUNKED_ARRAY_DATUM, C.int(ddof)))
+}
+
+func minMax(ptr unsafe.Pointer, kind C.int, skipNulls bool) (interface{}, interface{}, error) {
+	var cMax C.scalar_t
+	min, err := valueFromScalar(C.compute_min_max(ptr, kind, cBool(skipNulls), &cMax))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	max, err := valueFromScalar(cMax)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return min, max, nil
+}
+
+// FilterOptions are options for Filter
+type FilterOptions struct {
+	// EmitNulls emits a null for null mask values, by default they are dropped
+	EmitNulls bool
+}
+
+// TakeOptions are options for Take, out of bounds indices are an error
+type TakeOptions struct {
+	// DropNulls drops null indices, by default a null is emitted for them
+	DropNulls bool
+}
+
+// Filter returns a new array with values of a where mask (a BoolType array)
+// is true
+func (a *Array) Filter(mask *Array) (*Array, error) {
+	return a.FilterWithOptions(mask, FilterOptions{})
+}
+
+// FilterWithOptions is Filter with options
+func (a *Array) FilterWithOptions(mask *Array, opts FilterOptions) (*Array, error) {
+	r := C.compute_filter(a.ptr, C.ARRAY_DATUM, mask.ptr, cBool(opts.EmitNulls))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// Take returns a new array with values of a at indices (an Integer64Type
+// array)
+func (a *Array) Take(indices *Array) (*Array, error) {
+	return a.TakeWithOptions(indices, TakeOptions{})
+}
+
+// TakeWithOptions is Take with options
+func (a *Array) TakeWithOptions(indices *Array, opts TakeOptions) (*Array, error) {
+	r := C.compute_take(a.ptr, C.ARRAY_DATUM, indices.ptr, cBool(opts.DropNulls))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// Filter returns a new table with rows of t where mask (a BoolType array) is
+// true
+func (t *Table) Filter(mask *Array) (*Table, error) {
+	return t.FilterWithOptions(mask, FilterOptions{})
+}
+
+// FilterWithOptions is Filter with options
+func (t *Table) FilterWithOptions(mask *Array, opts FilterOptions) (*Table, error) {
+	r := C.compute_filter(t.ptr, C.TABLE_DATUM, mask.ptr, cBool(opts.EmitNulls))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
+
+// Take returns a new table with rows of t at indices (an Integer64Type array)
+func (t *Table) Take(indices *Array) (*Table, error) {
+	return t.TakeWithOptions(indices, TakeOptions{})
+}
+
+// TakeWithOptions is Take with options
+func (t *Table) TakeWithOptions(indices *Array, opts TakeOptions) (*Table, error) {
+	r := C.compute_take(t.ptr, C.TABLE_DATUM, indices.ptr, cBool(opts.DropNulls))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
+
+func cBool(b bool) C.int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// CompareOp is a comparison operator
+type CompareOp int
+
+// Comparison operators
+const (
+	OpEqual        CompareOp = C.CMP_EQUAL
+	OpNotEqual     CompareOp = C.CMP_NOT_EQUAL
+	OpLess         CompareOp = C.CMP_LESS
+	OpLessEqual    CompareOp = C.CMP_LESS_EQUAL
+	OpGreater      CompareOp = C.CMP_GREATER
+	OpGreaterEqual CompareOp = C.CMP_GREATER_EQUAL
+)
+
+// operand converts other, which is either an *Array or a Go scalar (bool,
+// int, int64, float64, string or time.Time) to C arguments
+// Call free after the C call
+func operand(other interface{}) (ptr unsafe.Pointer, scalar *C.scalar_t, free func(), err error) {
+	free = func() {}
+	if arr, ok := other.(*Array); ok {
+		return arr.ptr, nil, free, nil
+	}
+
+	scalar = &C.scalar_t{}
+	switch v := other.(type) {
+	case bool:
+		scalar.dtype = C.int(BoolType)
+		if v {
+			scalar.i = 1
+		}
+	case int:
+		scalar.dtype = C.int(Integer64Type)
+		scalar.i = C.int64_t(v)
+	case int64:
+		scalar.dtype = C.int(Integer64Type)
+		scalar.i = C.int64_t(v)
+	case float64:
+		scalar.dtype = C.int(Float64Type)
+		scalar.f = C.double(v)
+	case string:
+		cs := C.CString(v)
+		scalar.dtype = C.int(StringType)
+		scalar.s = cs
+		free = func() { C.free(unsafe.Pointer(cs)) }
+	case time.Time:
+		scalar.dtype = C.int(TimestampType)
+		scalar.i = C.int64_t(v.UnixNano())
+	default:
+		return nil, nil, free, fmt.Errorf("unsupported operand type: %T", other)
+	}
+
+	return nil, scalar, free, nil
+}
+
+// isNumeric returns true for types promote converts between
+func isNumeric(dtype DType) bool {
+	return dtype == Integer64Type || dtype == Float64Type
+}
+
+// promote converts integers to floats when comparing integers with floats
+func promote(left *Array, right interface{}) (*Array, interface{}, error) {
+	rightType := left.DType()
+	switch v := right.(type) {
+	case *Array:
+		rightType = v.DType()
+	case int64:
+		rightType = Integer64Type
+	case float64:
+		rightType = Float64Type
+	}
+
+	if !isNumeric(left.DType()) || !isNumeric(rightType) || left.DType() == rightType {
+		return left, right, nil
+	}
+
+	if left.DType() == Integer64Type {
+		arr, err := left.Cast(Float64Type, CastOptions{Safe: true})
+		return arr, right, err
+	}
+
+	switch v := right.(type) {
+	case *Array:
+		arr, err := v.Cast(Float64Type, CastOptions{Safe: true})
+		return left, arr, err
+	case int64:
+		return left, float64(v), nil
+	}
+
+	return left, right, nil
+}
+
+// Compare compares a element-wise to other and returns a BoolType array
+// other is either an *Array of the same length or a Go scalar (bool, int,
+// int64, float64, string or time.Time)
+// Integers are promoted to floats when compared with floats
+// Comparing to null returns null
+func (a *Array) Compare(op CompareOp, other interface{}) (*Array, error) {
+	if v, ok := other.(int); ok {
+		other = int64(v)
+	}
+
+	a, other, err := promote(a, other)
+	if err != nil {
+		return nil, err
+	}
+
+	ptr, scalar, free, err := operand(other)
+	if err != nil {
+		return nil, err
+	}
+	defer free()
+
+	r := C.compute_compare(a.ptr, C.int(op), ptr, scalar)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// EqualTo is Compare with OpEqual (Equal compares whole arrays)
+func (a *Array) EqualTo(other interface{}) (*Array, error) {
+	return a.Compare(OpEqual, other)
+}
+
+// NotEqual is Compare with OpNotEqual
+func (a *Array) NotEqual(other interface{}) (*Array, error) {
+	return a.Compare(OpNotEqual, other)
+}
+
+// Less is Compare with OpLess
+func (a *Array) Less(other interface{}) (*Array, error) {
+	return a.Compare(OpLess, other)
+}
+
+// LessEqual is Compare with OpLessEqual
+func (a *Array) LessEqual(other interface{}) (*Array, error) {
+	return a.Compare(OpLessEqual, other)
+}
+
+// Greater is Compare with OpGreater
+func (a *Array) Greater(other interface{}) (*Array, error) {
+	return a.Compare(OpGreater, other)
+}
+
+// GreaterEqual is Compare with OpGreaterEqual
+func (a *Array) GreaterEqual(other interface{}) (*Array, error) {
+	return a.Compare(OpGreaterEqual, other)
+}
+
+// arithmetic applies op on a and other (*Array or int/int64/float64)
+// The result is Integer64Type if both sides are integers, otherwise
+// Float64Type
+// Integer overflow and division by zero are errors
+func (a *Array) arithmetic(op C.int, other interface{}) (*Array, error) {
+	ptr, scalar, free, err := operand(other)
+	if err != nil {
+		return nil, err
+	}
+	defer free()
+
+	r := C.compute_arithmetic(a.ptr, op, ptr, scalar)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// Add returns a + other element-wise
+// other is either an *Array of the same length or a number
+func (a *Array) Add(other interface{}) (*Array, error) {
+	return a.arithmetic(C.ARITH_ADD, other)
+}
+
+// Subtract returns a - other element-wise
+// other is either an *Array of the same length or a number
+func (a *Array) Subtract(other interface{}) (*Array, error) {
+	return a.arithmetic(C.ARITH_SUBTRACT, other)
+}
+
+// Multiply returns a * other element-wise
+// other is either an *Array of the same length or a number
+func (a *Array) Multiply(other interface{}) (*Array, error) {
+	return a.arithmetic(C.ARITH_MULTIPLY, other)
+}
+
+// Divide returns a / other element-wise, integer division is used if both
+// sides are integers
+// other is either an *Array of the same length or a number
+func (a *Array) Divide(other interface{}) (*Array, error) {
+	return a.arithmetic(C.ARITH_DIVIDE, other)
+}
+
+// SortKey is a sort key for Table.SortBy
+type SortKey struct {
+	Name       string // Column name
+	Descending bool
+	NullsFirst bool
+}
+
+// SortIndices returns an Integer64Type array of indices that sort a in
+// ascending order, nulls are last
+// Use Take with the result to get the sorted array
+func (a *Array) SortIndices() (*Array, error) {
+	r := C.compute_sort_indices(a.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// SortBy returns a new table with rows of t sorted by keys
+// Sort is stable, rows with equal keys keep their relative order
+// NaN values are placed after all numbers, nulls are placed last unless
+// NullsFirst is set
+func (t *Table) SortBy(keys []SortKey) (*Table, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no sort keys")
+	}
+
+	columns := make([]C.int, 0, len(keys))
+	descending := make([]C.int, 0, len(keys))
+	nullsFirst := make([]C.int, 0, len(keys))
+	for _, key := range keys {
+		i, err := t.columnIndex(key.Name)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, C.int(i))
+		descending = append(descending, cBool(key.Descending))
+		nullsFirst = append(nullsFirst, cBool(key.NullsFirst))
+	}
+
+	r := C.table_sort_by(t.ptr, &columns[0], &descending[0], &nullsFirst[0], C.size_t(len(keys)))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
+
+// CastOptions are options for Cast
+type CastOptions struct {
+	// Safe fails on lossy conversions (overflow, truncation ...)
+	Safe bool
+}
+
+// Cast returns a new array with values of a converted to dtype
+func (a *Array) Cast(dtype DType, opts CastOptions) (*Array, error) {
+	r := C.compute_cast(a.ptr, C.int(dtype), cBool(opts.Safe))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// CastColumns returns a new table with columns converted to the types in
+// dtypes (column name -> type), lossy conversions fail (see CastOptions.Safe)
+func (t *Table) CastColumns(dtypes map[string]DType) (*Table, error) {
+	if len(dtypes) == 0 {
+		return t, nil
+	}
+
+	columns := make([]C.int, 0, len(dtypes))
+	cTypes := make([]C.int, 0, len(dtypes))
+	for name, dtype := range dtypes {
+		i, err := t.columnIndex(name)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, C.int(i))
+		cTypes = append(cTypes, C.int(dtype))
+	}
+
+	r := C.table_cast_columns(t.ptr, &columns[0], &cTypes[0], C.size_t(len(columns)), 1)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
+
+// Unique returns the distinct values of a, in order of first appearance
+func (a *Array) Unique() (*Array, error) {
+	r := C.compute_unique(a.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// ValueCounts returns the distinct values of a and an Integer64Type array of
+// how many times each value appears
+func (a *Array) ValueCounts() (*Array, *Array, error) {
+	r := C.compute_value_counts(a.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, nil, err
+	}
+	st := &Array{r.ptr}
+
+	r = C.array_struct_field(st.ptr, 0)
+	if err := errFromResult(r); err != nil {
+		return nil, nil, err
+	}
+	values := &Array{r.ptr}
+
+	r = C.array_struct_field(st.ptr, 1)
+	if err := errFromResult(r); err != nil {
+		return nil, nil, err
+	}
+	counts := &Array{r.ptr}
+
+	return values, counts, nil
+}
+
+// IsIn returns a BoolType array which is true where value of a is in values
+func (a *Array) IsIn(values *Array) (*Array, error) {
+	r := C.compute_is_in(a.ptr, values.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// DictionaryEncode returns a DictionaryType array with the values of a
+// Use Dictionary and DictionaryIndices to access the encoded array parts
+func (a *Array) DictionaryEncode() (*Array, error) {
+	r := C.compute_dictionary_encode(a.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// Dictionary returns the distinct values of a DictionaryType array
+func (a *Array) Dictionary() (*Array, error) {
+	r := C.array_dictionary(a.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// DictionaryIndices returns an Integer64Type array of indices into the
+// dictionary of a DictionaryType array
+func (a *Array) DictionaryIndices() (*Array, error) {
+	r := C.array_dictionary_indices(a.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// And returns a AND other element-wise, both must be BoolType arrays
+func (a *Array) And(other *Array) (*Array, error) {
+	return a.boolean(C.BOOL_AND, other)
+}
+
+// Or returns a OR other element-wise, both must be BoolType arrays
+func (a *Array) Or(other *Array) (*Array, error) {
+	return a.boolean(C.BOOL_OR, other)
+}
+
+// Not returns NOT a element-wise, a must be a BoolType array
+func (a *Array) Not() (*Array, error) {
+	return a.boolean(C.BOOL_NOT, nil)
+}
+
+func (a *Array) boolean(op C.int, other *Array) (*Array, error) {
+	var ptr unsafe.Pointer
+	if other != nil {
+		ptr = other.ptr
+	}
+
+	r := C.compute_boolean(a.ptr, op, ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// validMask returns a BoolType array which is true where a is valid (not
+// null), or where a is null if invert is true
+func (a *Array) validMask(invert bool) (*Array, error) {
+	r := C.compute_is_valid(a.ptr, cBool(invert))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
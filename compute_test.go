@@ -0,0 +1,441 @@
+package carrow
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	require := require.New(t)
+	arr := buildIntArray(require, 2, 4, 4, 4, 5, 5, 7, 9)
+
+	sum, err := arr.Sum()
+	require.NoError(err, "sum")
+	require.Equal(int64(40), sum, "sum")
+
+	mean, err := arr.Mean()
+	require.NoError(err, "mean")
+	require.InDelta(5.0, mean, 1e-9, "mean")
+
+	min, max, err := arr.MinMax(true)
+	require.NoError(err, "min max")
+	require.Equal(int64(2), min, "min")
+	require.Equal(int64(9), max, "max")
+
+	count, err := arr.Count(CountValid)
+	require.NoError(err, "count")
+	require.Equal(8, count, "count")
+
+	count, err = arr.Count(CountNulls)
+	require.NoError(err, "count nulls")
+	require.Equal(0, count, "count nulls")
+
+	variance, err := arr.Variance(0)
+	require.NoError(err, "variance")
+	require.InDelta(4.0, variance, 1e-9, "variance")
+
+	stddev, err := arr.Stddev(0)
+	require.NoError(err, "stddev")
+	require.InDelta(2.0, stddev, 1e-9, "stddev")
+}
+
+func TestAggregateChunked(t *testing.T) {
+	require := require.New(t)
+	t1 := buildTable(require, 10)
+	t2 := buildTable(require, 10)
+	table, err := ConcatenateTables(t1, t2)
+	require.NoError(err, "concatenate")
+
+	col, err := table.ChunkedColumn(1)
+	require.NoError(err, "ChunkedColumn(1)")
+	require.Equal(2, col.NumChunks(), "chunks")
+
+	sum, err := col.Sum()
+	require.NoError(err, "sum")
+	require.Equal(float64(90), sum, "sum")
+
+	mean, err := col.Mean()
+	require.NoError(err, "mean")
+	require.InDelta(4.5, mean, 1e-9, "mean")
+
+	min, max, err := col.MinMax(true)
+	require.NoError(err, "min max")
+	require.Equal(float64(0), min, "min")
+	require.Equal(float64(9), max, "max")
+
+	variance, err := col.Variance(0)
+	require.NoError(err, "variance")
+	require.InDelta(8.25, variance, 1e-9, "variance")
+}
+
+func buildBoolArray(require *require.Assertions, values ...bool) *Array {
+	b := NewBoolArrayBuilder()
+	require.NotNil(b, "create")
+	for _, v := range values {
+		err := b.Append(v)
+		require.NoErrorf(err, "append %v", v)
+	}
+
+	arr, err := b.Finish()
+	require.NoError(err, "finish")
+	return arr
+}
+
+func TestFilter(t *testing.T) {
+	require := require.New(t)
+	arr := buildIntArray(require, 1, 2, 3, 4)
+	mask := buildBoolArray(require, true, false, false, true)
+
+	out, err := arr.Filter(mask)
+	require.NoError(err, "filter")
+	ok, err := out.Equal(buildIntArray(require, 1, 4))
+	require.NoError(err, "equal")
+	require.True(ok, "filtered values")
+
+	table := buildTable(require, 4)
+	ft, err := table.Filter(mask)
+	require.NoError(err, "filter table")
+	require.Equal(2, ft.NumRows(), "filtered rows")
+	require.Equal(2, ft.NumCols(), "filtered columns")
+
+	_, err = arr.Filter(buildBoolArray(require, true))
+	require.Error(err, "bad mask length")
+}
+
+func TestTake(t *testing.T) {
+	require := require.New(t)
+	arr := buildIntArray(require, 10, 20, 30, 40)
+	indices := buildIntArray(require, 3, 0, 0)
+
+	out, err := arr.Take(indices)
+	require.NoError(err, "take")
+	ok, err := out.Equal(buildIntArray(require, 40, 10, 10))
+	require.NoError(err, "equal")
+	require.True(ok, "taken values")
+
+	table := buildTable(require, 10)
+	tt, err := table.TakeWithOptions(indices, TakeOptions{DropNulls: true})
+	require.NoError(err, "take table")
+	require.Equal(3, tt.NumRows(), "taken rows")
+
+	col, err := tt.Column(0)
+	require.NoError(err, "Column(0)")
+	v, err := col.Int64At(0)
+	require.NoError(err, "Int64At(0)")
+	require.Equal(int64(3), v, "first taken value")
+}
+
+func TestFilterNulls(t *testing.T) {
+	require := require.New(t)
+	arr := buildIntArray(require, 1, 2, 3)
+	b := NewBoolArrayBuilder()
+	require.NoError(b.Append(true), "append")
+	require.NoError(b.AppendNull(), "append null")
+	require.NoError(b.Append(true), "append")
+	mask, err := b.Finish()
+	require.NoError(err, "finish")
+
+	out, err := arr.Filter(mask)
+	require.NoError(err, "filter")
+	ok, err := out.Equal(buildIntArray(require, 1, 3))
+	require.NoError(err, "equal")
+	require.True(ok, "dropped null")
+
+	out, err = arr.FilterWithOptions(mask, FilterOptions{EmitNulls: true})
+	require.NoError(err, "filter emit nulls")
+	require.Equal(3, out.Length(), "length")
+	require.True(out.IsNull(1), "emitted null")
+
+	t1 := buildTable(require, 3)
+	t2 := buildTable(require, 3)
+	table, err := ConcatenateTables(t1, t2)
+	require.NoError(err, "concatenate")
+	mask = buildBoolArray(require, false, false, true, true, false, false)
+	ft, err := table.Filter(mask)
+	require.NoError(err, "filter chunked table")
+	require.Equal(2, ft.NumRows(), "filtered rows")
+	col, err := ft.Column(0)
+	require.NoError(err, "Column(0)")
+	v, err := col.Int64At(1)
+	require.NoError(err, "Int64At(1)")
+	require.Equal(int64(0), v, "value from second chunk")
+}
+
+func TestTakeNulls(t *testing.T) {
+	require := require.New(t)
+	arr := buildIntArray(require, 10, 20, 30)
+	b := NewInteger64ArrayBuilder()
+	require.NoError(b.Append(2), "append")
+	require.NoError(b.AppendNull(), "append null")
+	require.NoError(b.Append(0), "append")
+	indices, err := b.Finish()
+	require.NoError(err, "finish")
+
+	out, err := arr.Take(indices)
+	require.NoError(err, "take")
+	require.Equal(3, out.Length(), "length")
+	require.True(out.IsNull(1), "emitted null")
+
+	out, err = arr.TakeWithOptions(indices, TakeOptions{DropNulls: true})
+	require.NoError(err, "take drop nulls")
+	ok, err := out.Equal(buildIntArray(require, 30, 10))
+	require.NoError(err, "equal")
+	require.True(ok, "dropped null")
+
+	table := buildTable(require, 3)
+	tt, err := table.Take(indices)
+	require.NoError(err, "take table")
+	require.Equal(3, tt.NumRows(), "taken rows")
+
+	_, err = arr.Take(buildIntArray(require, 0, 3))
+	require.Error(err, "out of bounds")
+	_, err = table.Take(buildIntArray(require, 7))
+	require.Error(err, "table out of bounds")
+}
+
+func TestCompare(t *testing.T) {
+	require := require.New(t)
+	arr := buildIntArray(require, 1, 5, 10)
+
+	mask, err := arr.Greater(4)
+	require.NoError(err, "greater")
+	require.Equal(BoolType, mask.DType(), "mask dtype")
+	ok, err := mask.Equal(buildBoolArray(require, false, true, true))
+	require.NoError(err, "equal")
+	require.True(ok, "greater values")
+
+	mask, err = arr.EqualTo(buildIntArray(require, 1, 2, 10))
+	require.NoError(err, "equal to array")
+	ok, err = mask.Equal(buildBoolArray(require, true, false, true))
+	require.NoError(err, "equal")
+	require.True(ok, "equal to values")
+
+	out, err := arr.Filter(mask)
+	require.NoError(err, "filter")
+	require.Equal(2, out.Length(), "filtered length")
+
+	_, err = arr.Less(struct{}{})
+	require.Error(err, "bad operand")
+
+	_, err = arr.EqualTo(buildIntArray(require, 1, 2))
+	require.Error(err, "length mismatch")
+}
+
+func TestComparePromote(t *testing.T) {
+	require := require.New(t)
+	arr := buildIntArray(require, 1, 5, 10)
+	farr, err := arr.Cast(Float64Type, CastOptions{Safe: true})
+	require.NoError(err, "cast")
+
+	expected := buildBoolArray(require, false, true, true)
+	mask, err := farr.Greater(4)
+	require.NoError(err, "float greater int")
+	ok, err := mask.Equal(expected)
+	require.NoError(err, "equal")
+	require.True(ok, "float greater int values")
+
+	mask, err = arr.Greater(4.5)
+	require.NoError(err, "int greater float")
+	ok, err = mask.Equal(expected)
+	require.NoError(err, "equal")
+	require.True(ok, "int greater float values")
+
+	mask, err = farr.EqualTo(arr)
+	require.NoError(err, "float equal int array")
+	ok, err = mask.Equal(buildBoolArray(require, true, true, true))
+	require.NoError(err, "equal")
+	require.True(ok, "float equal int array values")
+}
+
+func TestArithmetic(t *testing.T) {
+	require := require.New(t)
+	arr := buildIntArray(require, 1, 2, 3)
+
+	out, err := arr.Add(buildIntArray(require, 10, 20, 30))
+	require.NoError(err, "add")
+	ok, err := out.Equal(buildIntArray(require, 11, 22, 33))
+	require.NoError(err, "equal")
+	require.True(ok, "add values")
+
+	out, err = arr.Multiply(2.5)
+	require.NoError(err, "multiply")
+	require.Equal(Float64Type, out.DType(), "multiply dtype")
+	v, err := out.Float64At(1)
+	require.NoError(err, "Float64At(1)")
+	require.Equal(5.0, v, "multiply value")
+
+	out, err = arr.Subtract(1)
+	require.NoError(err, "subtract")
+	ok, err = out.Equal(buildIntArray(require, 0, 1, 2))
+	require.NoError(err, "equal")
+	require.True(ok, "subtract values")
+
+	_, err = arr.Divide(0)
+	require.Error(err, "divide by zero")
+
+	big := buildIntArray(require, math.MaxInt64)
+	_, err = big.Add(1)
+	require.Error(err, "overflow")
+}
+
+func TestSort(t *testing.T) {
+	require := require.New(t)
+	arr := buildIntArray(require, 30, 10, 20)
+
+	indices, err := arr.SortIndices()
+	require.NoError(err, "sort indices")
+	ok, err := indices.Equal(buildIntArray(require, 1, 2, 0))
+	require.NoError(err, "equal")
+	require.True(ok, "sort indices values")
+
+	table := buildTable(require, 10)
+	sorted, err := table.SortBy([]SortKey{{Name: floatColName, Descending: true}})
+	require.NoError(err, "sort by")
+	require.Equal(10, sorted.NumRows(), "sorted rows")
+
+	col, err := sorted.Column(0)
+	require.NoError(err, "Column(0)")
+	v, err := col.Int64At(0)
+	require.NoError(err, "Int64At(0)")
+	require.Equal(int64(9), v, "first value")
+
+	_, err = table.SortBy([]SortKey{{Name: "no-such-column"}})
+	require.Error(err, "unknown column")
+}
+
+func TestSortByKeys(t *testing.T) {
+	require := require.New(t)
+	fb := NewFloat64ArrayBuilder()
+	for _, v := range []float64{2, math.NaN(), 1} {
+		require.NoErrorf(fb.Append(v), "append %v", v)
+	}
+	require.NoError(fb.AppendNull(), "append null")
+	require.NoError(fb.Append(2), "append")
+	require.NoError(fb.Append(1), "append")
+	values, err := fb.Finish()
+	require.NoError(err, "finish")
+
+	table := buildJoinTable(require,
+		[]string{"id", "group", "value"},
+		[]*Array{
+			buildIntArray(require, 0, 1, 2, 3, 4, 5),
+			buildStringArray(require, "a", "b", "a", "b", "a", "b"),
+			values,
+		},
+	)
+
+	ids := func(t *Table) []int64 {
+		col, err := t.Column(0)
+		require.NoError(err, "Column(0)")
+		var out []int64
+		for i := 0; i < col.Length(); i++ {
+			v, err := col.Int64At(i)
+			require.NoErrorf(err, "Int64At(%d)", i)
+			out = append(out, v)
+		}
+		return out
+	}
+
+	testCases := []struct {
+		name string
+		keys []SortKey
+		ids  []int64
+	}{
+		{"nan last", []SortKey{{Name: "value"}}, []int64{2, 5, 0, 4, 1, 3}},
+		{"nan last descending", []SortKey{{Name: "value", Descending: true}}, []int64{0, 4, 2, 5, 1, 3}},
+		{"nulls first", []SortKey{{Name: "value", NullsFirst: true}}, []int64{3, 2, 5, 0, 4, 1}},
+		{"multi key", []SortKey{{Name: "group"}, {Name: "value"}}, []int64{2, 0, 4, 5, 1, 3}},
+		{"descending secondary", []SortKey{{Name: "group"}, {Name: "value", Descending: true}}, []int64{0, 4, 2, 5, 1, 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			sorted, err := table.SortBy(tc.keys)
+			require.NoError(err, "sort by")
+			require.Equal(tc.ids, ids(sorted), "order")
+		})
+	}
+}
+
+func TestCast(t *testing.T) {
+	require := require.New(t)
+	arr := buildIntArray(require, 1, 2, 3)
+
+	out, err := arr.Cast(Float64Type, CastOptions{Safe: true})
+	require.NoError(err, "cast")
+	require.Equal(Float64Type, out.DType(), "cast dtype")
+	v, err := out.Float64At(2)
+	require.NoError(err, "Float64At(2)")
+	require.Equal(3.0, v, "cast value")
+
+	fb := NewFloat64ArrayBuilder()
+	require.NoError(fb.Append(1.5), "append")
+	farr, err := fb.Finish()
+	require.NoError(err, "finish")
+	_, err = farr.Cast(Integer64Type, CastOptions{Safe: true})
+	require.Error(err, "lossy safe cast")
+	_, err = farr.Cast(Integer64Type, CastOptions{Safe: false})
+	require.NoError(err, "lossy unsafe cast")
+
+	table := buildTable(require, 10)
+	ct, err := table.CastColumns(map[string]DType{intColName: Float64Type})
+	require.NoError(err, "cast columns")
+	fld, err := ct.Field(0)
+	require.NoError(err, "Field(0)")
+	require.Equal(intColName, fld.Name(), "field name")
+	require.Equal(Float64Type, fld.DType(), "field dtype")
+}
+
+func buildStringArray(require *require.Assertions, values ...string) *Array {
+	b := NewStringArrayBuilder()
+	require.NotNil(b, "create")
+	for _, v := range values {
+		err := b.Append(v)
+		require.NoErrorf(err, "append %q", v)
+	}
+
+	arr, err := b.Finish()
+	require.NoError(err, "finish")
+	return arr
+}
+
+func TestHash(t *testing.T) {
+	require := require.New(t)
+	arr := buildStringArray(require, "b", "a", "b", "c", "b")
+
+	unique, err := arr.Unique()
+	require.NoError(err, "unique")
+	ok, err := unique.Equal(buildStringArray(require, "b", "a", "c"))
+	require.NoError(err, "equal")
+	require.True(ok, "unique values")
+
+	values, counts, err := arr.ValueCounts()
+	require.NoError(err, "value counts")
+	ok, err = values.Equal(buildStringArray(require, "b", "a", "c"))
+	require.NoError(err, "equal")
+	require.True(ok, "value counts values")
+	ok, err = counts.Equal(buildIntArray(require, 3, 1, 1))
+	require.NoError(err, "equal")
+	require.True(ok, "value counts counts")
+
+	mask, err := arr.IsIn(buildStringArray(require, "a", "c"))
+	require.NoError(err, "is in")
+	ok, err = mask.Equal(buildBoolArray(require, false, true, false, true, false))
+	require.NoError(err, "equal")
+	require.True(ok, "is in values")
+
+	dict, err := arr.DictionaryEncode()
+	require.NoError(err, "dictionary encode")
+	require.Equal(DictionaryType, dict.DType(), "dictionary dtype")
+	indices, err := dict.DictionaryIndices()
+	require.NoError(err, "indices")
+	ok, err = indices.Equal(buildIntArray(require, 0, 1, 0, 2, 0))
+	require.NoError(err, "equal")
+	require.True(ok, "indices values")
+	dv, err := dict.Dictionary()
+	require.NoError(err, "dictionary")
+	require.Equal(3, dv.Length(), "dictionary length")
+}
@@ -2,7 +2,11 @@
 #include <arrow/csv/api.h>
 #include <arrow/io/api.h>
 
+#include <cstdlib>
+#include <cstring>
 #include <memory>
+#include <string>
+#include <vector>
 
 #include "_cgo_export.h"
 #include "csv.h"
@@ -12,6 +16,22 @@ struct Table {
   std::shared_ptr<arrow::Table> table;
 };
 
+namespace {
+
+// Same layout as the ones in carrow.cc
+struct Schema {
+	std::shared_ptr<arrow::Schema> ptr;
+};
+
+struct RecordBatch {
+	std::shared_ptr<arrow::RecordBatch> ptr;
+};
+
+struct StreamReader {
+	std::shared_ptr<arrow::csv::StreamingReader> reader;
+};
+
+} // namespace
 
 class GoStream: virtual public arrow::io::InputStream {
 	long long id_;
@@ -27,6 +47,7 @@ class GoStream: virtual public arrow::io::InputStream {
 		auto res = istream_tell(id_);
 		if (res.err != NULL) {
 			auto err = std::string(res.err);
+			free(res.err);
 			return arrow::Status::IOError(err);
 		}
 		return res.size;
@@ -35,6 +56,7 @@ class GoStream: virtual public arrow::io::InputStream {
 	bool closed() const {
 		auto res = istream_closed(id_);
 		if (res.err != NULL) {
+			free(res.err);
 			return true;
 		}
 
@@ -45,46 +67,129 @@ class GoStream: virtual public arrow::io::InputStream {
 		auto res = istream_read(id_, nbytes);
 		if (res.err != NULL) {
 			auto err = std::string(res.err);
+			free(res.err);
 			return arrow::Status::IOError(err);
 		}
 
+		// data is malloced by Go (C.CBytes), we own it
 		memcpy(out, res.data, res.size);
+		free(res.data);
 		return res.size;
 	}
 
 	arrow::Result<std::shared_ptr<arrow::Buffer>> Read(int64_t nbytes) {
-		auto res = istream_read(id_, nbytes);
-		if (res.err != NULL) {
-			auto err = std::string(res.err);
-			return arrow::Status(arrow::StatusCode::UnknownError, err);
+		ARROW_ASSIGN_OR_RAISE(auto buffer, arrow::AllocateResizableBuffer(nbytes));
+		ARROW_ASSIGN_OR_RAISE(auto n, Read(nbytes, buffer->mutable_data()));
+		ARROW_RETURN_NOT_OK(buffer->Resize(n));
+		return std::shared_ptr<arrow::Buffer>(std::move(buffer));
+	}
+};
+
+static std::shared_ptr<arrow::DataType> data_type(int dtype) {
+	switch (dtype) {
+	case arrow::Type::BOOL:
+		return arrow::boolean();
+	case arrow::Type::DOUBLE:
+		return arrow::float64();
+	case arrow::Type::INT64:
+		return arrow::int64();
+	case arrow::Type::STRING:
+		return arrow::utf8();
+	case arrow::Type::TIMESTAMP:
+		return arrow::timestamp(arrow::TimeUnit::NANO);
+	}
+
+	return nullptr;
+}
+
+static std::vector<std::string> strings(char **values, long long count) {
+	std::vector<std::string> out;
+	for (long long i = 0; i < count; i++) {
+		out.push_back(values[i]);
+	}
+	return out;
+}
+
+static arrow::Status make_options(csv_options_t *opts,
+		arrow::csv::ReadOptions *read_options,
+		arrow::csv::ParseOptions *parse_options,
+		arrow::csv::ConvertOptions *convert_options) {
+	*read_options = arrow::csv::ReadOptions::Defaults();
+	*parse_options = arrow::csv::ParseOptions::Defaults();
+	*convert_options = arrow::csv::ConvertOptions::Defaults();
+
+	parse_options->delimiter = opts->delimiter;
+	parse_options->quoting = opts->quote != 0;
+	parse_options->quote_char = opts->quote;
+	parse_options->escaping = opts->escape != 0;
+	parse_options->escape_char = opts->escape;
+
+	read_options->skip_rows = opts->skip_rows;
+	read_options->column_names = strings(opts->column_names, opts->ncolumn_names);
+	if (read_options->column_names.empty()) {
+		if (opts->header_rows == 0) {
+			read_options->autogenerate_column_names = true;
+		} else {
+			// Last header row has the column names
+			read_options->skip_rows += opts->header_rows - 1;
 		}
+	} else {
+		read_options->skip_rows += opts->header_rows;
+	}
 
-		auto data = (const uint8_t *)res.data;
-		return std::make_shared<arrow::Buffer>(data, res.size);
+	if (opts->block_size > 0) {
+		read_options->block_size = opts->block_size;
 	}
-};
+	read_options->use_threads = opts->use_threads != 0;
 
-read_res_t csv_read(long long id) {
+	for (long long i = 0; i < opts->ntypes; i++) {
+		auto dtype = data_type(opts->types[i]);
+		if (dtype == nullptr) {
+			return arrow::Status::TypeError(
+					"unsupported type for column ", opts->type_names[i]);
+		}
+		convert_options->column_types[opts->type_names[i]] = dtype;
+	}
+
+	if (opts->nnull_values >= 0) {
+		convert_options->null_values = strings(opts->null_values, opts->nnull_values);
+	}
+	if (opts->ntrue_values >= 0) {
+		convert_options->true_values = strings(opts->true_values, opts->ntrue_values);
+	}
+	if (opts->nfalse_values >= 0) {
+		convert_options->false_values = strings(opts->false_values, opts->nfalse_values);
+	}
+	convert_options->include_columns = strings(opts->include_columns, opts->ninclude_columns);
+
+	return arrow::Status::OK();
+}
+
+read_res_t csv_read(long long id, csv_options_t *opts) {
 	read_res_t res = {NULL, NULL};
 	arrow::MemoryPool* pool = arrow::default_memory_pool();
 	std::shared_ptr<arrow::io::InputStream> input = std::make_shared<GoStream>(id);
 
-	// TODO: Allow user to pass options
-	auto read_options = arrow::csv::ReadOptions::Defaults();
-	auto parse_options = arrow::csv::ParseOptions::Defaults();
-	auto convert_options = arrow::csv::ConvertOptions::Defaults();
-	
+	arrow::csv::ReadOptions read_options;
+	arrow::csv::ParseOptions parse_options;
+	arrow::csv::ConvertOptions convert_options;
+	auto status = make_options(opts, &read_options, &parse_options, &convert_options);
+	if (!status.ok()) {
+		res.err = strdup(status.message().c_str());
+		return res;
+	}
+
 	auto ptr = arrow::csv::TableReader::Make(pool, input, read_options,
 			parse_options, convert_options);
 	if (!ptr.ok()) {
-		res.err = ptr.status().message().c_str();
+		res.err = strdup(ptr.status().message().c_str());
 		return res;
 	}
 	
 	std::shared_ptr<arrow::csv::TableReader> reader = ptr.ValueOrDie();
 	auto rptr = reader->Read();
 	if (!rptr.ok()) {
-		res.err = rptr.status().message().c_str();
+		res.err = strdup(rptr.status().message().c_str());
 		return res;
 	}
 
@@ -93,3 +198,70 @@ read_res_t csv_read(long long id) {
 	res.table = tp;
 	return res;
 }
+
+ptr_res_t csv_stream_reader_new(long long id, csv_options_t *opts) {
+	ptr_res_t res = {NULL, NULL};
+	arrow::MemoryPool* pool = arrow::default_memory_pool();
+	std::shared_ptr<arrow::io::InputStream> input = std::make_shared<GoStream>(id);
+
+	arrow::csv::ReadOptions read_options;
+	arrow::csv::ParseOptions parse_options;
+	arrow::csv::ConvertOptions convert_options;
+	auto status = make_options(opts, &read_options, &parse_options, &convert_options);
+	if (!status.ok()) {
+		res.err = strdup(status.message().c_str());
+		return res;
+	}
+
+	// Schema is inferred from the first block
+	auto ptr = arrow::csv::StreamingReader::Make(pool, input, read_options,
+			parse_options, convert_options);
+	if (!ptr.ok()) {
+		res.err = strdup(ptr.status().message().c_str());
+		return res;
+	}
+
+	auto rp = new StreamReader;
+	rp->reader = ptr.ValueOrDie();
+	res.ptr = rp;
+	return res;
+}
+
+void *csv_stream_reader_schema(void *vp) {
+	auto rp = (StreamReader *)vp;
+	if (rp == NULL) {
+		return NULL;
+	}
+
+	auto schema = new Schema;
+	schema->ptr = rp->reader->schema();
+	return schema;
+}
+
+// ptr is NULL at end of stream
+ptr_res_t csv_stream_reader_next(void *vp) {
+	ptr_res_t res = {NULL, NULL};
+	auto rp = (StreamReader *)vp;
+	if (rp == NULL) {
+		res.err = strdup("null pointer");
+		return res;
+	}
+
+	std::shared_ptr<arrow::RecordBatch> batch;
+	auto status = rp->reader->ReadNext(&batch);
+	if (!status.ok()) {
+		res.err = strdup(status.message().c_str());
+		return res;
+	}
+
+	if (batch != nullptr) {
+		auto bp = new RecordBatch;
+		bp->ptr = batch;
+		res.ptr = bp;
+	}
+	return res;
+}
+
+void csv_stream_reader_free(void *vp) {
+	delete (StreamReader *)vp;
+}
@@ -3,6 +3,7 @@ package csv
 import (
 	"fmt"
 	"io"
+	"sync"
 	"unsafe"
 
 	"github.com/353solutions/carrow"
@@ -12,6 +13,7 @@ import (
 #cgo pkg-config: arrow plasma
 
 #include "csv.h"
+#include <stdlib.h>
 */
 import "C"
 
@@ -27,11 +29,15 @@ type inStream struct {
 }
 
 type Registry struct {
+	mu     sync.Mutex
 	reg    map[int]*inStream
 	nextID int
 }
 
 func (r *Registry) Alloc(is *inStream) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	id := r.nextID
 	r.nextID++
 	r.reg[id] = is
@@ -39,10 +45,16 @@ func (r *Registry) Alloc(is *inStream) int {
 }
 
 func (r *Registry) Get(id int) *inStream {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	return r.reg[id]
 }
 
 func (r *Registry) Release(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	delete(r.reg, id)
 }
 
@@ -109,15 +121,129 @@ func istream_closed(id int) C.csv_res_t {
 	return res
 }
 
-// Reads a CSV data from rdr, returns a *carrow.Table
+// Options are options for ReadWithOptions, start from DefaultOptions
+type Options struct {
+	Delimiter   byte
+	Quote       byte     // 0 disables quoting
+	Escape      byte     // 0 disables escaping
+	HeaderRows  int      // Rows before the data, the last one has the column names
+	ColumnNames []string // If set, header rows are skipped and these are used
+	SkipRows    int      // Rows to skip before the header
+	ColumnTypes map[string]carrow.DType
+	// If nil, Arrow defaults are used (e.g. "NA", "null" ...)
+	NullValues     []string
+	TrueValues     []string
+	FalseValues    []string
+	IncludeColumns []string // Columns to read, all if empty
+	BlockSize      int      // Bytes to process at a time, 0 for Arrow default
+	UseThreads     bool
+}
+
+// DefaultOptions returns the options used by Read
+func DefaultOptions() Options {
+	return Options{
+		Delimiter:  ',',
+		Quote:      '"',
+		HeaderRows: 1,
+		UseThreads: true,
+	}
+}
+
+// cStrings returns values in C memory, call free when done
+// count is -1 if values is nil
+func cStrings(values []string) (ptr **C.char, count C.longlong, free func()) {
+	if values == nil {
+		return nil, -1, func() {}
+	}
+
+	size := C.size_t(len(values)+1) * C.size_t(unsafe.Sizeof((*C.char)(nil)))
+	arr := (*[1 << 28]*C.char)(C.malloc(size))[: len(values)+1 : len(values)+1]
+	for i, v := range values {
+		arr[i] = C.CString(v)
+	}
+
+	free = func() {
+		for _, cp := range arr[:len(values)] {
+			C.free(unsafe.Pointer(cp))
+		}
+		C.free(unsafe.Pointer(&arr[0]))
+	}
+
+	return &arr[0], C.longlong(len(values)), free
+}
+
+// cOptions returns opts in C memory, call free when done
+func cOptions(opts Options) (*C.csv_options_t, func()) {
+	var frees []func()
+	free := func() {
+		for _, fn := range frees {
+			fn()
+		}
+	}
+
+	co := (*C.csv_options_t)(C.calloc(1, C.size_t(unsafe.Sizeof(C.csv_options_t{}))))
+	frees = append(frees, func() { C.free(unsafe.Pointer(co)) })
+
+	co.delimiter = C.char(opts.Delimiter)
+	co.quote = C.char(opts.Quote)
+	co.escape = C.char(opts.Escape)
+	co.header_rows = C.int(opts.HeaderRows)
+	co.skip_rows = C.int(opts.SkipRows)
+	co.block_size = C.longlong(opts.BlockSize)
+	if opts.UseThreads {
+		co.use_threads = 1
+	}
+
+	var fn func()
+	co.column_names, co.ncolumn_names, fn = cStrings(opts.ColumnNames)
+	frees = append(frees, fn)
+	co.null_values, co.nnull_values, fn = cStrings(opts.NullValues)
+	frees = append(frees, fn)
+	co.true_values, co.ntrue_values, fn = cStrings(opts.TrueValues)
+	frees = append(frees, fn)
+	co.false_values, co.nfalse_values, fn = cStrings(opts.FalseValues)
+	frees = append(frees, fn)
+	co.include_columns, co.ninclude_columns, fn = cStrings(opts.IncludeColumns)
+	frees = append(frees, fn)
+
+	if len(opts.ColumnTypes) > 0 {
+		names := make([]string, 0, len(opts.ColumnTypes))
+		for name := range opts.ColumnTypes {
+			names = append(names, name)
+		}
+		co.type_names, co.ntypes, fn = cStrings(names)
+		frees = append(frees, fn)
+
+		size := C.size_t(len(names)) * C.size_t(unsafe.Sizeof(C.int(0)))
+		types := (*[1 << 28]C.int)(C.malloc(size))[:len(names):len(names)]
+		for i, name := range names {
+			types[i] = C.int(opts.ColumnTypes[name])
+		}
+		co.types = &types[0]
+		frees = append(frees, func() { C.free(unsafe.Pointer(&types[0])) })
+	}
+
+	return co, free
+}
+
+// Read reads CSV data from rdr with DefaultOptions, returns a *carrow.Table
 func Read(rdr io.Reader) (*carrow.Table, error) {
+	return ReadWithOptions(rdr, DefaultOptions())
+}
+
+// ReadWithOptions reads CSV data from rdr with opts, returns a *carrow.Table
+func ReadWithOptions(rdr io.Reader, opts Options) (*carrow.Table, error) {
+	co, free := cOptions(opts)
+	defer free()
+
 	is := &inStream{rdr: rdr}
 	id := reg.Alloc(is)
 	defer reg.Release(id)
-	res := C.csv_read(C.longlong(id))
+	res := C.csv_read(C.longlong(id), co)
 	if res.err != nil {
-		// TODO: Free res.err?
-		return nil, fmt.Errorf(C.GoString(res.err))
+		err := fmt.Errorf(C.GoString(res.err))
+		C.free(unsafe.Pointer(res.err))
+		return nil, err
 	}
 
 	ptr := unsafe.Pointer(res.table)
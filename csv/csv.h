@@ -5,6 +5,8 @@
 extern "C" {
 #endif
 
+#include <stddef.h>
+
 typedef struct {
 	void *data;
 	unsigned long long size;
@@ -17,7 +19,41 @@ typedef struct {
 } read_res_t;
 
 
-read_res_t csv_read(long long id);
+// Strings lists with count -1 keep Arrow defaults
+typedef struct {
+	char delimiter;
+	char quote; // 0 disables quoting
+	char escape; // 0 disables escaping
+	int header_rows;
+	int skip_rows;
+	char **column_names;
+	long long ncolumn_names;
+	char **type_names;
+	int *types;
+	long long ntypes;
+	char **null_values;
+	long long nnull_values;
+	char **true_values;
+	long long ntrue_values;
+	char **false_values;
+	long long nfalse_values;
+	char **include_columns;
+	long long ninclude_columns;
+	long long block_size; // 0 for Arrow default
+	int use_threads;
+} csv_options_t;
+
+read_res_t csv_read(long long id, csv_options_t *opts);
+
+typedef struct {
+	void *ptr;
+	const char *err;
+} ptr_res_t;
+
+ptr_res_t csv_stream_reader_new(long long id, csv_options_t *opts);
+void *csv_stream_reader_schema(void *vp);
+ptr_res_t csv_stream_reader_next(void *vp);
+void csv_stream_reader_free(void *vp);
 
 #ifdef __cplusplus
 }
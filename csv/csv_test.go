@@ -2,8 +2,11 @@ package csv
 
 import (
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/353solutions/carrow"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,3 +21,90 @@ func TestRead(t *testing.T) {
 	require.Equal(4, table.NumCols(), "columns")
 	require.Equal(4, table.NumRows(), "rows")
 }
+
+func TestReadWithOptions(t *testing.T) {
+	require := require.New(t)
+	data := "# exported\n1;apple;NONE\n2;'b;anana';3.5\n"
+
+	opts := DefaultOptions()
+	opts.Delimiter = ';'
+	opts.Quote = '\''
+	opts.SkipRows = 1
+	opts.HeaderRows = 0
+	opts.ColumnNames = []string{"id", "name", "price"}
+	opts.ColumnTypes = map[string]carrow.DType{"id": carrow.Float64Type}
+	opts.NullValues = []string{"NONE"}
+
+	table, err := ReadWithOptions(strings.NewReader(data), opts)
+	require.NoError(err, "read")
+	require.Equal(3, table.NumCols(), "columns")
+	require.Equal(2, table.NumRows(), "rows")
+
+	ids, err := table.ColumnByName("id")
+	require.NoError(err, "id column")
+	require.Equal(carrow.Float64Type, ids.DType(), "id dtype")
+
+	names, err := table.ColumnByName("name")
+	require.NoError(err, "name column")
+	name, err := names.StringAt(1)
+	require.NoError(err, "StringAt(1)")
+	require.Equal("b;anana", name, "quoted name")
+
+	prices, err := table.ColumnByName("price")
+	require.NoError(err, "price column")
+	require.Equal(carrow.Float64Type, prices.DType(), "price dtype")
+
+	opts.IncludeColumns = []string{"name"}
+	table, err = ReadWithOptions(strings.NewReader(data), opts)
+	require.NoError(err, "read include")
+	require.Equal(1, table.NumCols(), "include columns")
+
+	opts = DefaultOptions()
+	opts.ColumnTypes = map[string]carrow.DType{"id": carrow.DictionaryType}
+	_, err = ReadWithOptions(strings.NewReader(data), opts)
+	require.Error(err, "unsupported column type")
+}
+
+func TestReadTimestampUnit(t *testing.T) {
+	require := require.New(t)
+	// Arrow infers CSV timestamps in seconds, carrow works in nanoseconds
+	data := "t\n2020-05-01 10:20:30\n2020-05-02 00:00:00\n"
+	table, err := Read(strings.NewReader(data))
+	require.NoError(err, "read")
+	col, err := table.Column(0)
+	require.NoError(err, "Column(0)")
+	require.Equal(carrow.TimestampType, col.DType(), "dtype")
+
+	first := time.Date(2020, 5, 1, 10, 20, 30, 0, time.UTC)
+	ts, err := col.TimeAt(0)
+	require.NoError(err, "TimeAt(0)")
+	require.True(first.Equal(ts), "time value")
+
+	mask, err := col.Greater(first)
+	require.NoError(err, "greater")
+	require.False(mask.IsNull(0), "not null")
+	ok, err := mask.BoolAt(1)
+	require.NoError(err, "BoolAt(1)")
+	require.True(ok, "greater value")
+
+	b := carrow.NewTimestampArrayBuilder()
+	require.NoError(b.Append(first), "append")
+	require.NoError(b.Append(first), "append")
+	nanos, err := b.Finish()
+	require.NoError(err, "finish")
+	mask, err = col.EqualTo(nanos)
+	require.NoError(err, "equal to nanoseconds")
+	v0, err := mask.BoolAt(0)
+	require.NoError(err, "BoolAt(0)")
+	v1, err := mask.BoolAt(1)
+	require.NoError(err, "BoolAt(1)")
+	require.Equal([]bool{true, false}, []bool{v0, v1}, "equal values")
+
+	out, err := table.GroupBy().Aggregate(carrow.Agg{Col: "t", Func: carrow.AggMax})
+	require.NoError(err, "max")
+	col, err = out.Column(0)
+	require.NoError(err, "max column")
+	ts, err = col.TimeAt(0)
+	require.NoError(err, "max TimeAt(0)")
+	require.True(time.Date(2020, 5, 2, 0, 0, 0, 0, time.UTC).Equal(ts), "max value")
+}
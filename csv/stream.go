@@ -0,0 +1,94 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"unsafe"
+
+	"github.com/353solutions/carrow"
+)
+
+/*
+#cgo pkg-config: arrow plasma
+
+#include "csv.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// StreamReader reads CSV data incrementally as record batches
+type StreamReader struct {
+	ptr unsafe.Pointer
+	id  int
+}
+
+// NewStreamReader returns a StreamReader reading CSV data from rdr with opts
+// Column types are inferred from the first block (see Options.BlockSize), use
+// Options.ColumnTypes if later values don't fit
+func NewStreamReader(rdr io.Reader, opts Options) (*StreamReader, error) {
+	co, free := cOptions(opts)
+	defer free()
+
+	is := &inStream{rdr: rdr}
+	id := reg.Alloc(is)
+	res := C.csv_stream_reader_new(C.longlong(id), co)
+	if res.err != nil {
+		reg.Release(id)
+		err := fmt.Errorf(C.GoString(res.err))
+		C.free(unsafe.Pointer(res.err))
+		return nil, err
+	}
+
+	r := &StreamReader{res.ptr, id}
+	// Release the reader & its stream id if Close isn't called
+	runtime.SetFinalizer(r, func(r *StreamReader) {
+		r.Close()
+	})
+	return r, nil
+}
+
+// Schema returns the stream schema
+func (r *StreamReader) Schema() *carrow.Schema {
+	ptr := C.csv_stream_reader_schema(r.ptr)
+	if ptr == nil {
+		return nil
+	}
+
+	return carrow.NewSchemaFromPtr(ptr)
+}
+
+// Next returns the next record batch, at end of data it returns io.EOF
+func (r *StreamReader) Next() (*carrow.RecordBatch, error) {
+	if r.ptr == nil {
+		return nil, fmt.Errorf("read from closed reader")
+	}
+
+	res := C.csv_stream_reader_next(r.ptr)
+	if res.err != nil {
+		err := fmt.Errorf(C.GoString(res.err))
+		C.free(unsafe.Pointer(res.err))
+		return nil, err
+	}
+
+	if res.ptr == nil {
+		return nil, io.EOF
+	}
+
+	return carrow.NewRecordBatchFromPtr(res.ptr), nil
+}
+
+// Close releases the reader resources, it does not close rdr
+// Call Close when done, unclosed readers are released only when garbage
+// collected
+func (r *StreamReader) Close() error {
+	if r.ptr == nil {
+		return nil
+	}
+
+	runtime.SetFinalizer(r, nil)
+	C.csv_stream_reader_free(r.ptr)
+	r.ptr = nil
+	reg.Release(r.id)
+	return nil
+}
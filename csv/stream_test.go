@@ -0,0 +1,41 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamReader(t *testing.T) {
+	require := require.New(t)
+	var sb strings.Builder
+	sb.WriteString("id,name\n")
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&sb, "%d,name-%d\n", i, i)
+	}
+
+	opts := DefaultOptions()
+	opts.BlockSize = 1 << 10
+	r, err := NewStreamReader(strings.NewReader(sb.String()), opts)
+	require.NoError(err, "new reader")
+	defer r.Close()
+	require.Contains(r.Schema().String(), "id: int64", "schema")
+
+	batches, rows := 0, 0
+	for {
+		batch, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err, "next")
+		require.Equal(2, batch.NumCols(), "columns")
+		batches++
+		rows += batch.NumRows()
+	}
+
+	require.Equal(1000, rows, "rows")
+	require.True(batches > 1, "single batch")
+}
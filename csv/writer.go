@@ -0,0 +1,152 @@
+package csv
+
+import (
+	gocsv "encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/353solutions/carrow"
+)
+
+// WriteOptions are options for Write
+type WriteOptions struct {
+	Header          bool   // Write column names as first row
+	Delimiter       rune   // Default to ','
+	NullString      string // Written for null values
+	TimestampFormat string // Go time layout, default to time.RFC3339Nano (UTC)
+}
+
+// Write writes table to w as CSV, one record batch at a time
+// Values are quoted when needed
+// Timestamps in any unit are written with TimestampFormat. Nested columns
+// (ListType & StructType) have no CSV representation and return an error.
+func Write(w io.Writer, table *carrow.Table, opts WriteOptions) error {
+	cw := gocsv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		cw.Comma = opts.Delimiter
+	}
+	if opts.TimestampFormat == "" {
+		opts.TimestampFormat = time.RFC3339Nano
+	}
+
+	if opts.Header {
+		names, err := table.ColumnNames()
+		if err != nil {
+			return err
+		}
+		if err := cw.Write(names); err != nil {
+			return err
+		}
+	}
+
+	batches, err := table.Batches(0)
+	if err != nil {
+		return err
+	}
+
+	record := make([]string, table.NumCols())
+	for _, batch := range batches {
+		formatters := make([]valueFormatter, batch.NumCols())
+		for i := range formatters {
+			arr, err := batch.Column(i)
+			if err != nil {
+				return err
+			}
+			formatters[i], err = newFormatter(arr, opts)
+			if err != nil {
+				return err
+			}
+		}
+
+		for row := 0; row < batch.NumRows(); row++ {
+			for col, format := range formatters {
+				record[col], err = format(row)
+				if err != nil {
+					return err
+				}
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// valueFormatter returns the CSV representation of value at row
+type valueFormatter func(row int) (string, error)
+
+func newFormatter(arr *carrow.Array, opts WriteOptions) (valueFormatter, error) {
+	var format valueFormatter
+	switch arr.DType() {
+	case carrow.BoolType:
+		format = func(row int) (string, error) {
+			v, err := arr.BoolAt(row)
+			return strconv.FormatBool(v), err
+		}
+	case carrow.Float64Type:
+		format = func(row int) (string, error) {
+			v, err := arr.Float64At(row)
+			return strconv.FormatFloat(v, 'g', -1, 64), err
+		}
+	case carrow.Integer64Type:
+		format = func(row int) (string, error) {
+			v, err := arr.Int64At(row)
+			return strconv.FormatInt(v, 10), err
+		}
+	case carrow.StringType:
+		format = arr.StringAt
+	case carrow.TimestampType:
+		format = func(row int) (string, error) {
+			v, err := arr.TimeAt(row)
+			return v.UTC().Format(opts.TimestampFormat), err
+		}
+	case carrow.DictionaryType:
+		return dictionaryFormatter(arr, opts)
+	default:
+		// ListType, StructType ...
+		return nil, fmt.Errorf("unsupported column type: %s", arr.DType())
+	}
+
+	return func(row int) (string, error) {
+		if arr.IsNull(row) {
+			return opts.NullString, nil
+		}
+		return format(row)
+	}, nil
+}
+
+// dictionaryFormatter formats the dictionary values of arr
+func dictionaryFormatter(arr *carrow.Array, opts WriteOptions) (valueFormatter, error) {
+	dict, err := arr.Dictionary()
+	if err != nil {
+		return nil, err
+	}
+	indices, err := arr.DictionaryIndices()
+	if err != nil {
+		return nil, err
+	}
+	format, err := newFormatter(dict, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(row int) (string, error) {
+		if indices.IsNull(row) {
+			return opts.NullString, nil
+		}
+		i, err := indices.Int64At(row)
+		if err != nil {
+			return "", err
+		}
+		return format(int(i))
+	}, nil
+}
@@ -0,0 +1,110 @@
+package csv
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/353solutions/carrow"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	require := require.New(t)
+	file, err := os.Open("cart.csv")
+	require.NoError(err, "open cart.csv")
+	defer file.Close()
+	table, err := Read(file)
+	require.NoError(err, "read csv")
+
+	var buf bytes.Buffer
+	err = Write(&buf, table, WriteOptions{Header: true})
+	require.NoError(err, "write")
+
+	out, err := Read(&buf)
+	require.NoError(err, "read written")
+	ok, err := out.Equal(table)
+	require.NoError(err, "equal")
+	require.True(ok, "round trip")
+}
+
+func TestWriteOptions(t *testing.T) {
+	require := require.New(t)
+
+	sb := carrow.NewStringArrayBuilder()
+	require.NoError(sb.Append("a;b"), "append")
+	require.NoError(sb.Append(`say "hi"`), "append")
+	strs, err := sb.Finish()
+	require.NoError(err, "finish strings")
+
+	tb := carrow.NewTimestampArrayBuilder()
+	require.NoError(tb.Append(time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)), "append")
+	require.NoError(tb.Append(time.Date(2020, 5, 2, 0, 0, 0, 0, time.UTC)), "append")
+	times, err := tb.Finish()
+	require.NoError(err, "finish times")
+
+	strField, err := carrow.NewField("s", carrow.StringType)
+	require.NoError(err, "string field")
+	timeField, err := carrow.NewField("t", carrow.TimestampType)
+	require.NoError(err, "time field")
+	schema, err := carrow.NewSchema([]*carrow.Field{strField, timeField})
+	require.NoError(err, "schema")
+	table, err := carrow.NewTableFromArrays(schema, []*carrow.Array{strs, times})
+	require.NoError(err, "table")
+
+	var buf bytes.Buffer
+	opts := WriteOptions{Delimiter: ';', TimestampFormat: "2006-01-02"}
+	require.NoError(Write(&buf, table, opts), "write")
+	expected := "\"a;b\";2020-05-01\n\"say \"\"hi\"\"\";2020-05-02\n"
+	require.Equal(expected, buf.String(), "output")
+
+	table, err = Read(strings.NewReader("x,y\n1,\n2,3\n"))
+	require.NoError(err, "read nulls")
+	buf.Reset()
+	require.NoError(Write(&buf, table, WriteOptions{NullString: "NA"}), "write nulls")
+	require.Equal("1,NA\n2,3\n", buf.String(), "null output")
+}
+
+func TestWriteTimestampUnit(t *testing.T) {
+	require := require.New(t)
+	// CSV infers timestamps in seconds
+	data := "id,t\n1,2020-05-01 10:20:30\n2,1970-01-01 00:00:01\n"
+	table, err := Read(strings.NewReader(data))
+	require.NoError(err, "read")
+
+	var buf bytes.Buffer
+	require.NoError(Write(&buf, table, WriteOptions{}), "write")
+	expected := "1,2020-05-01T10:20:30Z\n2,1970-01-01T00:00:01Z\n"
+	require.Equal(expected, buf.String(), "output")
+
+	buf.Reset()
+	opts := WriteOptions{Header: true, TimestampFormat: "2006-01-02 15:04:05"}
+	require.NoError(Write(&buf, table, opts), "write header")
+	require.Equal(data, buf.String(), "header output")
+
+	out, err := Read(&buf)
+	require.NoError(err, "read written")
+	ok, err := out.Equal(table)
+	require.NoError(err, "equal")
+	require.True(ok, "round trip")
+}
+
+func TestWriteNested(t *testing.T) {
+	require := require.New(t)
+	sb := carrow.NewStringArrayBuilder()
+	require.NoError(sb.Append("a,b"), "append")
+	strs, err := sb.Finish()
+	require.NoError(err, "finish")
+	lists, err := strs.Split(",", -1)
+	require.NoError(err, "split")
+	require.Equal(carrow.ListType, lists.DType(), "dtype")
+
+	// Nested types (ListType, StructType) go through the unsupported path
+	_, err = newFormatter(lists, WriteOptions{})
+	require.Error(err, "list column")
+
+	_, err = newFormatter(strs, WriteOptions{})
+	require.NoError(err, "string column")
+}
@@ -0,0 +1,410 @@
+package carrow
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+/*
+#include "carrow.h"
+#include <stdlib.h>
+*/
+import "C"
+
+var (
+	compareOps = map[string]CompareOp{
+		"==": OpEqual,
+		"!=": OpNotEqual,
+		"<":  OpLess,
+		"<=": OpLessEqual,
+		">":  OpGreater,
+		">=": OpGreaterEqual,
+	}
+
+	arithmeticOps = map[string]func(*Array, interface{}) (*Array, error){
+		"+": (*Array).Add,
+		"-": (*Array).Subtract,
+		"*": (*Array).Multiply,
+		"/": (*Array).Divide,
+	}
+)
+
+// Expr is an expression over table columns, see Table.Where & Table.Project
+//
+//	expr := carrow.Col("price").Gt(carrow.Lit(10)).And(carrow.Col("qty").IsValid())
+type Expr struct {
+	op    string
+	name  string      // column name
+	value interface{} // literal value
+	alias string
+	args  []*Expr
+}
+
+// Col is a column reference
+func Col(name string) *Expr {
+	return &Expr{op: "col", name: name}
+}
+
+// Lit is a literal value (bool, int, int64, float64, string or time.Time)
+func Lit(value interface{}) *Expr {
+	if i, ok := value.(int); ok {
+		value = int64(i)
+	}
+	return &Expr{op: "lit", value: value}
+}
+
+func (e *Expr) binary(op string, other *Expr) *Expr {
+	return &Expr{op: op, args: []*Expr{e, other}}
+}
+
+// Eq is e == other
+func (e *Expr) Eq(other *Expr) *Expr { return e.binary("==", other) }
+
+// Ne is e != other
+func (e *Expr) Ne(other *Expr) *Expr { return e.binary("!=", other) }
+
+// Lt is e < other
+func (e *Expr) Lt(other *Expr) *Expr { return e.binary("<", other) }
+
+// Le is e <= other
+func (e *Expr) Le(other *Expr) *Expr { return e.binary("<=", other) }
+
+// Gt is e > other
+func (e *Expr) Gt(other *Expr) *Expr { return e.binary(">", other) }
+
+// Ge is e >= other
+func (e *Expr) Ge(other *Expr) *Expr { return e.binary(">=", other) }
+
+// Add is e + other
+func (e *Expr) Add(other *Expr) *Expr { return e.binary("+", other) }
+
+// Sub is e - other
+func (e *Expr) Sub(other *Expr) *Expr { return e.binary("-", other) }
+
+// Mul is e * other
+func (e *Expr) Mul(other *Expr) *Expr { return e.binary("*", other) }
+
+// Div is e / other
+func (e *Expr) Div(other *Expr) *Expr { return e.binary("/", other) }
+
+// And is e AND other
+func (e *Expr) And(other *Expr) *Expr { return e.binary("and", other) }
+
+// Or is e OR other
+func (e *Expr) Or(other *Expr) *Expr { return e.binary("or", other) }
+
+// Not is NOT e
+func (e *Expr) Not() *Expr {
+	return &Expr{op: "not", args: []*Expr{e}}
+}
+
+// IsValid is true where e is not null
+func (e *Expr) IsValid() *Expr {
+	return &Expr{op: "is_valid", args: []*Expr{e}}
+}
+
+// IsNull is true where e is null
+func (e *Expr) IsNull() *Expr {
+	return &Expr{op: "is_null", args: []*Expr{e}}
+}
+
+// As sets the name of e in Project output
+func (e *Expr) As(name string) *Expr {
+	out := *e
+	out.alias = name
+	return &out
+}
+
+// Name is the name of e in Project output
+func (e *Expr) Name() string {
+	switch {
+	case e.alias != "":
+		return e.alias
+	case e.op == "col":
+		return e.name
+	}
+
+	return e.String()
+}
+
+func (e *Expr) String() string {
+	switch e.op {
+	case "col":
+		return e.name
+	case "lit":
+		if s, ok := e.value.(string); ok {
+			return fmt.Sprintf("%q", s)
+		}
+		return fmt.Sprintf("%v", e.value)
+	case "not", "is_valid", "is_null":
+		return fmt.Sprintf("%s(%s)", e.op, e.args[0])
+	}
+
+	return fmt.Sprintf("(%s %s %s)", e.args[0], e.op, e.args[1])
+}
+
+func (e *Expr) typeError(format string, args ...interface{}) error {
+	return fmt.Errorf("type error in %s: %s", e, fmt.Sprintf(format, args...))
+}
+
+func litType(value interface{}) (DType, bool) {
+	switch value.(type) {
+	case bool:
+		return BoolType, true
+	case int64:
+		return Integer64Type, true
+	case float64:
+		return Float64Type, true
+	case string:
+		return StringType, true
+	case time.Time:
+		return TimestampType, true
+	}
+
+	return -1, false
+}
+
+// check validates e against column types, returns the type of e
+func (e *Expr) check(types map[string]DType) (DType, error) {
+	if e.op == "col" {
+		dtype, ok := types[e.name]
+		if !ok {
+			return -1, fmt.Errorf("column %q not found", e.name)
+		}
+		return dtype, nil
+	}
+
+	if e.op == "lit" {
+		dtype, ok := litType(e.value)
+		if !ok {
+			return -1, fmt.Errorf("unsupported literal type: %T", e.value)
+		}
+		return dtype, nil
+	}
+
+	argTypes := make([]DType, 0, len(e.args))
+	for _, arg := range e.args {
+		dtype, err := arg.check(types)
+		if err != nil {
+			return -1, err
+		}
+		argTypes = append(argTypes, dtype)
+	}
+
+	if e.op == "is_valid" || e.op == "is_null" {
+		return BoolType, nil
+	}
+
+	if e.op == "not" {
+		if argTypes[0] != BoolType {
+			return -1, e.typeError("not of %s", argTypes[0])
+		}
+		return BoolType, nil
+	}
+
+	lt, rt := argTypes[0], argTypes[1]
+	if e.args[0].op == "lit" && e.args[1].op == "lit" {
+		return -1, e.typeError("no columns")
+	}
+
+	switch e.op {
+	case "and", "or":
+		if lt != BoolType || rt != BoolType {
+			return -1, e.typeError("%s of %s and %s", e.op, lt, rt)
+		}
+		return BoolType, nil
+	case "+", "-", "*", "/":
+		if !isNumeric(lt) || !isNumeric(rt) {
+			return -1, e.typeError("arithmetic on %s and %s", lt, rt)
+		}
+		if lt == Integer64Type && rt == Integer64Type {
+			return Integer64Type, nil
+		}
+		return Float64Type, nil
+	}
+
+	// comparison
+	if lt != rt && !(isNumeric(lt) && isNumeric(rt)) {
+		return -1, e.typeError("can't compare %s with %s", lt, rt)
+	}
+	return BoolType, nil
+}
+
+// eval evaluates e on t
+func (e *Expr) eval(t *Table) (*Array, error) {
+	switch e.op {
+	case "col":
+		return t.ColumnByName(e.name)
+	case "lit":
+		return literalArray(e.value, t.NumRows())
+	}
+
+	left, err := e.args[0].eval(t)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "not":
+		return left.Not()
+	case "is_valid":
+		return left.validMask(false)
+	case "is_null":
+		return left.validMask(true)
+	}
+
+	// Literals on the right side are passed as scalars
+	var right interface{} = e.args[1].value
+	if e.args[1].op != "lit" {
+		arr, err := e.args[1].eval(t)
+		if err != nil {
+			return nil, err
+		}
+		right = arr
+	}
+
+	// Compare promotes integers to floats
+	if op, ok := compareOps[e.op]; ok {
+		return left.Compare(op, right)
+	}
+
+	if fn, ok := arithmeticOps[e.op]; ok {
+		return fn(left, right)
+	}
+
+	arr, ok := right.(*Array)
+	if !ok {
+		if arr, err = literalArray(right, t.NumRows()); err != nil {
+			return nil, err
+		}
+	}
+
+	switch e.op {
+	case "and":
+		return left.And(arr)
+	case "or":
+		return left.Or(arr)
+	}
+
+	return nil, fmt.Errorf("unknown operator: %s", e.op)
+}
+
+// literalArray returns an array of size n with value
+func literalArray(value interface{}, n int) (*Array, error) {
+	var bld interface {
+		Finish() (*Array, error)
+	}
+	var appendValue func() error
+
+	switch v := value.(type) {
+	case bool:
+		b := NewBoolArrayBuilder()
+		bld, appendValue = b, func() error { return b.Append(v) }
+	case int64:
+		b := NewInteger64ArrayBuilder()
+		bld, appendValue = b, func() error { return b.Append(v) }
+	case float64:
+		b := NewFloat64ArrayBuilder()
+		bld, appendValue = b, func() error { return b.Append(v) }
+	case string:
+		b := NewStringArrayBuilder()
+		bld, appendValue = b, func() error { return b.Append(v) }
+	case time.Time:
+		b := NewTimestampArrayBuilder()
+		bld, appendValue = b, func() error { return b.Append(v) }
+	default:
+		return nil, fmt.Errorf("unsupported literal type: %T", value)
+	}
+
+	for i := 0; i < n; i++ {
+		if err := appendValue(); err != nil {
+			return nil, err
+		}
+	}
+
+	return bld.Finish()
+}
+
+// columnTypes returns map of column name -> type
+func (t *Table) columnTypes() (map[string]DType, error) {
+	types := make(map[string]DType)
+	for i := 0; i < t.NumCols(); i++ {
+		fld, err := t.Field(i)
+		if err != nil {
+			return nil, err
+		}
+		types[fld.Name()] = fld.DType()
+	}
+
+	return types, nil
+}
+
+// Where returns a new table with rows of t where expr is true
+// expr is validated against t schema before evaluation
+func (t *Table) Where(expr *Expr) (*Table, error) {
+	types, err := t.columnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	dtype, err := expr.check(types)
+	if err != nil {
+		return nil, err
+	}
+
+	if dtype != BoolType {
+		return nil, fmt.Errorf("where expression %s is %s, not %s", expr, dtype, BoolType)
+	}
+
+	mask, err := expr.eval(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Filter(mask)
+}
+
+// Project returns a new table with a column for each expression
+// Expressions are validated against t schema before evaluation
+func (t *Table) Project(exprs ...*Expr) (*Table, error) {
+	if len(exprs) == 0 {
+		return nil, fmt.Errorf("no expressions")
+	}
+
+	types, err := t.columnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, expr := range exprs {
+		if _, err := expr.check(types); err != nil {
+			return nil, err
+		}
+	}
+
+	arrs := make([]unsafe.Pointer, 0, len(exprs))
+	names := make([]*C.char, 0, len(exprs))
+	defer func() {
+		for _, cp := range names {
+			C.free(unsafe.Pointer(cp))
+		}
+	}()
+
+	for _, expr := range exprs {
+		arr, err := expr.eval(t)
+		if err != nil {
+			return nil, err
+		}
+		arrs = append(arrs, arr.ptr)
+		names = append(names, C.CString(expr.Name()))
+	}
+
+	aptr := (unsafe.Pointer)(&arrs[0])
+	r := C.table_from_named_arrays(aptr, &names[0], C.size_t(len(arrs)))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
@@ -0,0 +1,63 @@
+package carrow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhere(t *testing.T) {
+	require := require.New(t)
+	table := buildTable(require, 20)
+
+	expr := Col(intColName).Gt(Lit(4)).And(Col(floatColName).Lt(Lit(10.0)))
+	require.Equal("((intCol > 5) and (floatCol < 10))", Col(intColName).Gt(Lit(5)).And(Col(floatColName).Lt(Lit(10))).String(), "string")
+
+	out, err := table.Where(expr)
+	require.NoError(err, "where")
+	require.Equal(5, out.NumRows(), "rows")
+
+	out, err = table.Where(Col(intColName).IsValid())
+	require.NoError(err, "where valid")
+	require.Equal(20, out.NumRows(), "valid rows")
+
+	out, err = table.Where(Col(intColName).Lt(Col(floatColName)).Not())
+	require.NoError(err, "where column compare")
+	require.Equal(20, out.NumRows(), "column compare rows")
+
+	_, err = table.Where(Col(intColName).Gt(Lit("x")))
+	require.Error(err, "compare int with string")
+	require.Contains(err.Error(), "can't compare", "type error message")
+
+	_, err = table.Where(Col(intColName))
+	require.Error(err, "non boolean where")
+
+	_, err = table.Where(Col("no-such-column").IsNull())
+	require.Error(err, "unknown column")
+}
+
+func TestProject(t *testing.T) {
+	require := require.New(t)
+	table := buildTable(require, 10)
+
+	out, err := table.Project(
+		Col(intColName),
+		Col(intColName).Mul(Lit(2)).As("double"),
+		Col(floatColName).Add(Col(intColName)),
+	)
+	require.NoError(err, "project")
+	require.Equal(10, out.NumRows(), "rows")
+
+	names, err := out.ColumnNames()
+	require.NoError(err, "ColumnNames")
+	require.Equal([]string{intColName, "double", "(floatCol + intCol)"}, names, "names")
+
+	col, err := out.ColumnByName("double")
+	require.NoError(err, "double column")
+	v, err := col.Int64At(3)
+	require.NoError(err, "Int64At(3)")
+	require.Equal(int64(6), v, "double value")
+
+	_, err = table.Project(Col(intColName).Add(Lit(true)))
+	require.Error(err, "add bool")
+}
@@ -0,0 +1,99 @@
+#include <arrow/api.h>
+#include <arrow/io/api.h>
+#include <arrow/ipc/feather.h>
+
+#include <cstring>
+#include <memory>
+#include <sstream>
+#include <string>
+#include <vector>
+
+#include "feather.h"
+
+#define FEATHER_RETURN_IF_ERROR(status)                                        \
+  do {                                                                         \
+    if (!status.ok()) {                                                        \
+      return result_t{strdup(status.message().c_str()), nullptr};              \
+    }                                                                          \
+  } while (false)
+
+namespace {
+
+// Same layout as the one in carrow.cc
+struct Table {
+  std::shared_ptr<arrow::Table> ptr;
+};
+
+} // namespace
+
+// Reads all columns if count is 0
+result_t feather_read(const char *path, char **columns, size_t count) {
+  auto file = arrow::io::ReadableFile::Open(path);
+  FEATHER_RETURN_IF_ERROR(file.status());
+
+  auto res = arrow::ipc::feather::Reader::Open(file.ValueOrDie());
+  FEATHER_RETURN_IF_ERROR(res.status());
+  auto reader = res.ValueOrDie();
+
+  std::shared_ptr<arrow::Table> table;
+  arrow::Status status;
+  if (count == 0) {
+    status = reader->Read(&table);
+  } else {
+    std::vector<std::string> names;
+    for (size_t i = 0; i < count; i++) {
+      if (reader->schema()->GetFieldIndex(columns[i]) == -1) {
+        std::ostringstream oss;
+        oss << "column " << columns[i] << " not found";
+        return result_t{strdup(oss.str().c_str()), nullptr};
+      }
+      names.push_back(columns[i]);
+    }
+    status = reader->Read(names, &table);
+  }
+  FEATHER_RETURN_IF_ERROR(status);
+
+  auto wrapper = new Table;
+  wrapper->ptr = table;
+  return result_t{nullptr, wrapper};
+}
+
+// Writes Feather V2 (Arrow IPC file), chunk_size <= 0 uses Arrow default
+result_t feather_write(const char *path, void *tp, int compression,
+                       int64_t chunk_size) {
+  auto table = (Table *)tp;
+  if (table == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto props = arrow::ipc::feather::WriteProperties::Defaults();
+  switch (compression) {
+  case FEATHER_UNCOMPRESSED:
+    props.compression = arrow::Compression::UNCOMPRESSED;
+    break;
+  case FEATHER_LZ4:
+    props.compression = arrow::Compression::LZ4_FRAME;
+    break;
+  case FEATHER_ZSTD:
+    props.compression = arrow::Compression::ZSTD;
+    break;
+  default:
+    return result_t{strdup("unknown compression"), nullptr};
+  }
+
+  if (chunk_size > 0) {
+    props.chunksize = chunk_size;
+  }
+
+  auto res = arrow::io::FileOutputStream::Open(path);
+  FEATHER_RETURN_IF_ERROR(res.status());
+  auto stream = res.ValueOrDie();
+
+  auto status =
+      arrow::ipc::feather::WriteTable(*table->ptr, stream.get(), props);
+  FEATHER_RETURN_IF_ERROR(status);
+  status = stream->Close();
+  FEATHER_RETURN_IF_ERROR(status);
+
+  return result_t{nullptr, nullptr};
+}
@@ -0,0 +1,87 @@
+// Package feather reads and writes Feather V2 files (e.g. pandas
+// DataFrame.to_feather)
+package feather
+
+import (
+	"unsafe"
+
+	"github.com/353solutions/carrow"
+	"github.com/353solutions/carrow/internal/bridge"
+)
+
+/*
+#cgo pkg-config: arrow plasma
+
+#include "feather.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// Compression is file compression
+type Compression int
+
+// Supported compressions
+const (
+	Uncompressed Compression = C.FEATHER_UNCOMPRESSED
+	LZ4          Compression = C.FEATHER_LZ4
+	ZSTD         Compression = C.FEATHER_ZSTD
+)
+
+func (c Compression) String() string {
+	switch c {
+	case Uncompressed:
+		return "uncompressed"
+	case LZ4:
+		return "lz4"
+	case ZSTD:
+		return "zstd"
+	}
+
+	return "<unknown>"
+}
+
+// Options are options for Write
+type Options struct {
+	Compression Compression // Default to Uncompressed
+	ChunkSize   int         // Maximal rows in a record batch, 0 for Arrow default
+}
+
+// errFromResult returns the error in r, see bridge.Error
+func errFromResult(r C.result_t) error {
+	return bridge.Error(unsafe.Pointer(r.err))
+}
+
+// Read reads a table from feather file at path
+// If columns are given, only these columns are read
+func Read(path string, columns ...string) (*carrow.Table, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	names := make([]*C.char, 0, len(columns)+1)
+	for _, name := range columns {
+		names = append(names, C.CString(name))
+	}
+	defer func() {
+		for _, cp := range names {
+			C.free(unsafe.Pointer(cp))
+		}
+	}()
+
+	// Avoid &names[0] on empty slice
+	cNames := append(names, nil)
+	r := C.feather_read(cPath, &cNames[0], C.size_t(len(columns)))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return carrow.NewTableFromPtr(r.ptr), nil
+}
+
+// Write writes table to path in feather V2 format
+func Write(path string, table *carrow.Table, opts Options) error {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	r := C.feather_write(cPath, table.Ptr(), C.int(opts.Compression), C.int64_t(opts.ChunkSize))
+	return errFromResult(r)
+}
@@ -0,0 +1,29 @@
+#ifndef CARROW_FEATHER_H
+#define CARROW_FEATHER_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+#include <stddef.h>
+#include <stdint.h>
+
+typedef struct {
+  const char *err;
+  void *ptr;
+  int64_t i;
+} result_t;
+
+#define FEATHER_UNCOMPRESSED 0
+#define FEATHER_LZ4 1
+#define FEATHER_ZSTD 2
+
+result_t feather_read(const char *path, char **columns, size_t count);
+result_t feather_write(const char *path, void *tp, int compression,
+                       int64_t chunk_size);
+
+#ifdef __cplusplus
+}
+#endif // extern "C"
+
+#endif // CARROW_FEATHER_H
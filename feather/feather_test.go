@@ -0,0 +1,64 @@
+package feather
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/353solutions/carrow"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTable(require *require.Assertions, size int) *carrow.Table {
+	ib := carrow.NewInteger64ArrayBuilder()
+	fb := carrow.NewFloat64ArrayBuilder()
+	for i := 0; i < size; i++ {
+		require.NoError(ib.Append(int64(i)), "append int")
+		require.NoError(fb.Append(float64(i)/2), "append float")
+	}
+	ints, err := ib.Finish()
+	require.NoError(err, "finish ints")
+	floats, err := fb.Finish()
+	require.NoError(err, "finish floats")
+
+	intField, err := carrow.NewField("i", carrow.Integer64Type)
+	require.NoError(err, "int field")
+	floatField, err := carrow.NewField("f", carrow.Float64Type)
+	require.NoError(err, "float field")
+	schema, err := carrow.NewSchema([]*carrow.Field{intField, floatField})
+	require.NoError(err, "schema")
+
+	table, err := carrow.NewTableFromArrays(schema, []*carrow.Array{ints, floats})
+	require.NoError(err, "table")
+	return table
+}
+
+func TestFeather(t *testing.T) {
+	require := require.New(t)
+	dir, err := ioutil.TempDir("", "carrow-feather")
+	require.NoError(err, "temp dir")
+	defer os.RemoveAll(dir)
+
+	table := buildTable(require, 100)
+	for _, compression := range []Compression{Uncompressed, LZ4, ZSTD} {
+		path := filepath.Join(dir, compression.String()+".feather")
+		err := Write(path, table, Options{Compression: compression})
+		require.NoErrorf(err, "write %s", compression)
+
+		out, err := Read(path)
+		require.NoErrorf(err, "read %s", compression)
+		ok, err := out.Equal(table)
+		require.NoError(err, "equal")
+		require.Truef(ok, "round trip %s", compression)
+	}
+
+	path := filepath.Join(dir, Uncompressed.String()+".feather")
+	out, err := Read(path, "f")
+	require.NoError(err, "read columns")
+	require.Equal(1, out.NumCols(), "columns")
+	require.Equal(100, out.NumRows(), "rows")
+
+	_, err = Read(path, "no-such-column")
+	require.Error(err, "unknown column")
+}
@@ -0,0 +1,168 @@
+package carrow
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+/*
+#include "carrow.h"
+#include <stdlib.h>
+*/
+import "C"
+
+const (
+	defaultFormatRows = 5
+	nullRepr          = "null"
+)
+
+// FormatOptions are options for Table.Format
+type FormatOptions struct {
+	Head int // Number of rows from the start, 0 for default (5), -1 for none
+	Tail int // Number of rows from the end, 0 for default (5), -1 for none
+}
+
+// stringFromResult converts a result holding a C string to a Go string
+func stringFromResult(r C.result_t) string {
+	if err := errFromResult(r); err != nil {
+		return fmt.Sprintf("<error: %s>", err)
+	}
+
+	s := C.GoString((*C.char)(r.ptr))
+	C.free(r.ptr)
+	return s
+}
+
+func (s *Schema) String() string {
+	return stringFromResult(C.schema_to_string(s.ptr))
+}
+
+func (a *Array) String() string {
+	return stringFromResult(C.array_to_string(a.ptr))
+}
+
+func (c *ChunkedArray) String() string {
+	return stringFromResult(C.chunked_array_to_string(c.ptr))
+}
+
+func (t *Table) String() string {
+	return stringFromResult(C.table_to_string(t.ptr))
+}
+
+// Format writes an aligned preview of the first and last rows of t to w
+func (t *Table) Format(w io.Writer, opts FormatOptions) error {
+	head, tail := formatRows(opts.Head), formatRows(opts.Tail)
+	ncols, nrows := t.NumCols(), t.NumRows()
+	names := make([]string, 0, ncols)
+	dtypes := make([]string, 0, ncols)
+	for i := 0; i < ncols; i++ {
+		fld, err := t.Field(i)
+		if err != nil {
+			return err
+		}
+		names = append(names, fld.Name())
+		dtypes = append(dtypes, fld.DType().String())
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\t%s\n", strings.Join(names, "\t"))
+	fmt.Fprintf(tw, "\t%s\n", strings.Join(dtypes, "\t"))
+
+	// writeRows writes length rows from offset, only these rows are copied
+	writeRows := func(offset, length int) error {
+		if length == 0 {
+			return nil
+		}
+
+		part, err := t.Slice(offset, length)
+		if err != nil {
+			return err
+		}
+		cols := make([]*Array, 0, ncols)
+		for i := 0; i < ncols; i++ {
+			col, err := part.Column(i)
+			if err != nil {
+				return err
+			}
+			cols = append(cols, col)
+		}
+
+		for row := 0; row < length; row++ {
+			cells := make([]string, 0, ncols)
+			for _, col := range cols {
+				cell, err := formatValue(col, row)
+				if err != nil {
+					return err
+				}
+				cells = append(cells, cell)
+			}
+			_, err := fmt.Fprintf(tw, "%d\t%s\n", offset+row, strings.Join(cells, "\t"))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if nrows <= head+tail {
+		if err := writeRows(0, nrows); err != nil {
+			return err
+		}
+	} else {
+		if err := writeRows(0, head); err != nil {
+			return err
+		}
+		fmt.Fprintf(tw, "...\t%s\n", strings.Repeat("...\t", ncols))
+		if err := writeRows(nrows-tail, tail); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n[%d rows x %d columns]\n", nrows, ncols)
+	return err
+}
+
+// formatRows returns the number of rows to show for a FormatOptions value
+func formatRows(n int) int {
+	switch {
+	case n == 0:
+		return defaultFormatRows
+	case n < 0:
+		return 0
+	}
+
+	return n
+}
+
+// formatValue returns string representation of value at row i of arr
+func formatValue(arr *Array, i int) (string, error) {
+	if C.array_is_null(arr.ptr, C.longlong(i)) == 1 {
+		return nullRepr, nil
+	}
+
+	switch arr.DType() {
+	case BoolType:
+		v, err := arr.BoolAt(i)
+		return fmt.Sprintf("%v", v), err
+	case Float64Type:
+		v, err := arr.Float64At(i)
+		return fmt.Sprintf("%g", v), err
+	case Integer64Type:
+		v, err := arr.Int64At(i)
+		return fmt.Sprintf("%d", v), err
+	case StringType:
+		return arr.StringAt(i)
+	case TimestampType:
+		v, err := arr.TimeAt(i)
+		return v.UTC().Format(time.RFC3339Nano), err
+	}
+
+	return "<unknown>", nil
+}
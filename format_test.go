@@ -0,0 +1,52 @@
+package carrow
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require := require.New(t)
+	table := buildTable(require, 10)
+
+	require.Contains(table.String(), intColName, "table string")
+	require.Contains(table.Schema().String(), floatColName, "schema string")
+
+	arr, err := table.Column(0)
+	require.NoError(err, "Column(0)")
+	require.True(strings.HasPrefix(arr.String(), "["), "array string")
+}
+
+func TestFormat(t *testing.T) {
+	require := require.New(t)
+	table := buildTable(require, 100)
+
+	var buf bytes.Buffer
+	err := table.Format(&buf, FormatOptions{Head: 3, Tail: 2})
+	require.NoError(err, "format")
+
+	out := buf.String()
+	require.Contains(out, intColName, "column name")
+	require.Contains(out, Float64Type.String(), "column type")
+	require.Contains(out, "...", "ellipsis")
+	require.Contains(out, "[100 rows x 2 columns]", "summary")
+	// 2 header lines, 3 head, 1 ellipsis, 2 tail, blank, summary
+	require.Equal(10, strings.Count(out, "\n"), "number of lines")
+
+	buf.Reset()
+	err = table.Format(&buf, FormatOptions{Head: 4, Tail: -1})
+	require.NoError(err, "format no tail")
+	out = buf.String()
+	require.Contains(out, "...", "ellipsis")
+	require.NotContains(out, "99 ", "last row")
+	// 2 header lines, 4 head, 1 ellipsis, blank, summary
+	require.Equal(9, strings.Count(out, "\n"), "number of lines no tail")
+
+	buf.Reset()
+	err = buildTable(require, 3).Format(&buf, FormatOptions{})
+	require.NoError(err, "format small")
+	require.NotContains(buf.String(), "...", "no ellipsis")
+}
@@ -16,6 +16,8 @@ import (
 
 func main() {
 	arrowTypes := []string{"Bool", "Float64", "Integer64", "String", "Timestamp"}
+	// Types without array builders
+	otherTypes := []string{"Dictionary", "List", "Struct"}
 	f, err := os.Create("carrow_generated.go")
 	die(err)
 	defer f.Close()
@@ -23,9 +25,11 @@ func main() {
 	packageTemplate.Execute(f, struct {
 		Timestamp  time.Time
 		ArrowTypes []string
+		OtherTypes []string
 	}{
 		Timestamp:  time.Now(),
 		ArrowTypes: arrowTypes,
+		OtherTypes: otherTypes,
 	})
 }
 
@@ -87,6 +91,9 @@ var(
 {{- range $val := .ArrowTypes}}
 	{{$val}}Type = DType(C.{{$val | ToUpper }}_DTYPE)
 {{- end}}
+{{- range $val := .OtherTypes}}
+	{{$val}}Type = DType(C.{{$val | ToUpper }}_DTYPE)
+{{- end}}
 )
 
 // Array Builders
@@ -115,6 +122,10 @@ func (dt DType) String() string {
 {{- range $val := .ArrowTypes}}
 	case {{$val}}Type:
 		return "{{$val}}"
+{{- end}}
+{{- range $val := .OtherTypes}}
+	case {{$val}}Type:
+		return "{{$val}}"
 {{- end}}
 	}
 
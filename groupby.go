@@ -0,0 +1,123 @@
+package carrow
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+/*
+#include "carrow.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// AggFunc is an aggregation function
+type AggFunc int
+
+// Aggregation functions
+const (
+	AggSum           AggFunc = C.AGG_SUM
+	AggMean          AggFunc = C.AGG_MEAN
+	AggMin           AggFunc = C.AGG_MIN
+	AggMax           AggFunc = C.AGG_MAX
+	AggCount         AggFunc = C.AGG_COUNT // Number of non-null values
+	AggCountDistinct AggFunc = C.AGG_COUNT_DISTINCT
+)
+
+func (f AggFunc) String() string {
+	switch f {
+	case AggSum:
+		return "sum"
+	case AggMean:
+		return "mean"
+	case AggMin:
+		return "min"
+	case AggMax:
+		return "max"
+	case AggCount:
+		return "count"
+	case AggCountDistinct:
+		return "count_distinct"
+	}
+
+	return "<unknown>"
+}
+
+// Agg is an aggregation of column Col with Func
+// If OutName is empty the output column is named <Col>_<Func> (e.g. price_sum)
+type Agg struct {
+	Col     string
+	Func    AggFunc
+	OutName string
+}
+
+// GroupBy is a table grouped by key columns, see Table.GroupBy
+type GroupBy struct {
+	table *Table
+	keys  []string
+}
+
+// GroupBy groups t by keys columns, use Aggregate on the result to compute
+// aggregations
+// If no keys are given, the whole table is one group (one row, even for an
+// empty table)
+func (t *Table) GroupBy(keys ...string) *GroupBy {
+	return &GroupBy{t, keys}
+}
+
+// Aggregate returns a new table with the key columns followed by a column for
+// every aggregation, one row per group
+// Null values are ignored, aggregations over groups without values are null
+// (counts are 0)
+func (g *GroupBy) Aggregate(aggs ...Agg) (*Table, error) {
+	if len(aggs) == 0 {
+		return nil, fmt.Errorf("no aggregations")
+	}
+
+	keys := make([]C.int, 0, len(g.keys)+1)
+	for _, name := range g.keys {
+		i, err := g.table.columnIndex(name)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, C.int(i))
+	}
+
+	columns := make([]C.int, 0, len(aggs))
+	funcs := make([]C.int, 0, len(aggs))
+	names := make([]*C.char, 0, len(aggs))
+	defer func() {
+		for _, cp := range names {
+			C.free(unsafe.Pointer(cp))
+		}
+	}()
+
+	for _, agg := range aggs {
+		i, err := g.table.columnIndex(agg.Col)
+		if err != nil {
+			return nil, err
+		}
+
+		name := agg.OutName
+		if name == "" {
+			name = fmt.Sprintf("%s_%s", agg.Col, agg.Func)
+		}
+
+		columns = append(columns, C.int(i))
+		funcs = append(funcs, C.int(agg.Func))
+		names = append(names, C.CString(name))
+	}
+
+	// Avoid &keys[0] on empty slice
+	cKeys := append(keys, 0)
+	r := C.table_group_by(
+		g.table.ptr,
+		&cKeys[0], C.size_t(len(keys)),
+		&columns[0], &funcs[0], &names[0], C.size_t(len(aggs)),
+	)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
@@ -0,0 +1,145 @@
+package carrow
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func buildTableForGroupBy(require *require.Assertions) *Table {
+	keys := buildStringArray(require, "a", "b", "a", "c", "a", "b")
+	values := buildIntArray(require, 1, 2, 3, 4, 5, 6)
+
+	keyField, err := NewField("key", StringType)
+	require.NoError(err, "key field")
+	valueField, err := NewField("value", Integer64Type)
+	require.NoError(err, "value field")
+	schema, err := NewSchema([]*Field{keyField, valueField})
+	require.NoError(err, "schema")
+	table, err := NewTableFromArrays(schema, []*Array{keys, values})
+	require.NoError(err, "table")
+	return table
+}
+
+func TestGroupBy(t *testing.T) {
+	require := require.New(t)
+	table := buildTableForGroupBy(require)
+
+	out, err := table.GroupBy("key").Aggregate(
+		Agg{Col: "value", Func: AggSum},
+		Agg{Col: "value", Func: AggCount, OutName: "n"},
+		Agg{Col: "value", Func: AggMean},
+		Agg{Col: "value", Func: AggMax},
+	)
+	require.NoError(err, "aggregate")
+	require.Equal(3, out.NumRows(), "groups")
+
+	names, err := out.ColumnNames()
+	require.NoError(err, "ColumnNames")
+	require.Equal([]string{"key", "value_sum", "n", "value_mean", "value_max"}, names, "names")
+
+	col, err := out.ColumnByName("key")
+	require.NoError(err, "key column")
+	ok, err := col.Equal(buildStringArray(require, "a", "b", "c"))
+	require.NoError(err, "equal")
+	require.True(ok, "keys")
+
+	col, err = out.ColumnByName("value_sum")
+	require.NoError(err, "sum column")
+	ok, err = col.Equal(buildIntArray(require, 9, 8, 4))
+	require.NoError(err, "equal")
+	require.True(ok, "sums")
+
+	col, err = out.ColumnByName("n")
+	require.NoError(err, "count column")
+	ok, err = col.Equal(buildIntArray(require, 3, 2, 1))
+	require.NoError(err, "equal")
+	require.True(ok, "counts")
+
+	col, err = out.ColumnByName("value_mean")
+	require.NoError(err, "mean column")
+	v, err := col.Float64At(0)
+	require.NoError(err, "Float64At(0)")
+	require.InDelta(3.0, v, 1e-9, "mean")
+
+	out, err = table.GroupBy().Aggregate(Agg{Col: "key", Func: AggCountDistinct})
+	require.NoError(err, "global aggregate")
+	require.Equal(1, out.NumRows(), "global rows")
+	col, err = out.Column(0)
+	require.NoError(err, "Column(0)")
+	n, err := col.Int64At(0)
+	require.NoError(err, "Int64At(0)")
+	require.Equal(int64(3), n, "count distinct")
+
+	_, err = table.GroupBy("key").Aggregate(Agg{Col: "key", Func: AggSum})
+	require.Error(err, "sum of strings")
+}
+
+func TestGroupByFloatKeys(t *testing.T) {
+	require := require.New(t)
+	fb := NewFloat64ArrayBuilder()
+	keys := []float64{0, math.Copysign(0, -1), math.NaN(), math.Float64frombits(0x7ff8000000000001), 1}
+	for _, v := range keys {
+		require.NoErrorf(fb.Append(v), "append %v", v)
+	}
+	keyArr, err := fb.Finish()
+	require.NoError(err, "finish")
+
+	keyField, err := NewField("key", Float64Type)
+	require.NoError(err, "key field")
+	valueField, err := NewField("value", Integer64Type)
+	require.NoError(err, "value field")
+	schema, err := NewSchema([]*Field{keyField, valueField})
+	require.NoError(err, "schema")
+	values := buildIntArray(require, 1, 2, 3, 4, 5)
+	table, err := NewTableFromArrays(schema, []*Array{keyArr, values})
+	require.NoError(err, "table")
+
+	out, err := table.GroupBy("key").Aggregate(Agg{Col: "value", Func: AggSum})
+	require.NoError(err, "aggregate")
+	require.Equal(3, out.NumRows(), "groups")
+
+	col, err := out.ColumnByName("value_sum")
+	require.NoError(err, "sum column")
+	ok, err := col.Equal(buildIntArray(require, 3, 7, 5))
+	require.NoError(err, "equal")
+	require.True(ok, "sums")
+
+	out, err = table.GroupBy().Aggregate(Agg{Col: "key", Func: AggCountDistinct})
+	require.NoError(err, "count distinct")
+	col, err = out.Column(0)
+	require.NoError(err, "Column(0)")
+	n, err := col.Int64At(0)
+	require.NoError(err, "Int64At(0)")
+	require.Equal(int64(3), n, "count distinct")
+}
+
+func TestGroupByEmpty(t *testing.T) {
+	require := require.New(t)
+	table, err := buildTableForGroupBy(require).Slice(0, 0)
+	require.NoError(err, "slice")
+
+	out, err := table.GroupBy().Aggregate(
+		Agg{Col: "value", Func: AggCount},
+		Agg{Col: "value", Func: AggSum},
+		Agg{Col: "value", Func: AggMean},
+	)
+	require.NoError(err, "global aggregate")
+	require.Equal(1, out.NumRows(), "global rows")
+
+	col, err := out.Column(0)
+	require.NoError(err, "count column")
+	n, err := col.Int64At(0)
+	require.NoError(err, "Int64At(0)")
+	require.Equal(int64(0), n, "count")
+	for i := 1; i < out.NumCols(); i++ {
+		col, err := out.Column(i)
+		require.NoErrorf(err, "Column(%d)", i)
+		require.Truef(col.IsNull(0), "column %d is null", i)
+	}
+
+	out, err = table.GroupBy("key").Aggregate(Agg{Col: "value", Func: AggCount})
+	require.NoError(err, "keyed aggregate")
+	require.Equal(0, out.NumRows(), "keyed rows")
+}
@@ -0,0 +1,79 @@
+// Package bridge has the helpers carrow sub packages (ipc, json, parquet ...)
+// use to pass Go streams and errors to and from C++
+package bridge
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+// Registry maps ids passed to C++ to Go values (io.Reader, io.Writer ...)
+// Go pointers can't be kept by C++, callbacks get the value by id
+type Registry struct {
+	sync.Mutex
+	values map[int64]interface{}
+	nextID int64
+}
+
+// NewRegistry returns a new empty Registry
+func NewRegistry() *Registry {
+	return &Registry{values: make(map[int64]interface{})}
+}
+
+// Alloc registers v and returns its id
+func (r *Registry) Alloc(v interface{}) int64 {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.nextID
+	r.nextID++
+	r.values[id] = v
+	return id
+}
+
+// Get returns the value registered with id, nil if there's none
+func (r *Registry) Get(id int64) interface{} {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.values[id]
+}
+
+// Release removes id from the registry
+func (r *Registry) Release(id int64) {
+	r.Lock()
+	defer r.Unlock()
+
+	delete(r.values, id)
+}
+
+// Error returns the C error string cErr (allocated with malloc) as error and
+// frees it, nil if cErr is nil
+// cgo types are per package, callers pass result_t.err as unsafe.Pointer
+func Error(cErr unsafe.Pointer) error {
+	if cErr == nil {
+		return nil
+	}
+
+	err := fmt.Errorf(C.GoString((*C.char)(cErr)))
+	C.free(cErr)
+	return err
+}
+
+// Bytes returns a slice over size bytes of C memory at data without copying
+// The slice is valid only until the callback returns
+func Bytes(data unsafe.Pointer, size int64) []byte {
+	var buf []byte
+	hdr := (*reflect.SliceHeader)(unsafe.Pointer(&buf))
+	hdr.Data = uintptr(data)
+	hdr.Len = int(size)
+	hdr.Cap = int(size)
+	return buf
+}
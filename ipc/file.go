@@ -0,0 +1,99 @@
+package ipc
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/353solutions/carrow"
+)
+
+/*
+#cgo pkg-config: arrow plasma
+
+#include "ipc.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// WriteFile writes table to path in Arrow IPC file (random access) format
+// The file can be read by pyarrow.ipc.open_file
+func WriteFile(path string, table *carrow.Table) error {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	r := C.ipc_write_file(cPath, table.Ptr())
+	return errFromResult(r)
+}
+
+// File is an Arrow IPC file opened for reading
+type File struct {
+	ptr unsafe.Pointer
+}
+
+// OpenFile opens an Arrow IPC file at path
+// The file is memory mapped, record batches are read only when requested
+func OpenFile(path string) (*File, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	r := C.ipc_file_open(cPath)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &File{r.ptr}, nil
+}
+
+// Schema returns the file schema
+func (f *File) Schema() *carrow.Schema {
+	ptr := C.ipc_file_schema(f.ptr)
+	if ptr == nil {
+		return nil
+	}
+
+	return carrow.NewSchemaFromPtr(ptr)
+}
+
+// NumBatches returns the number of record batches in the file
+func (f *File) NumBatches() int {
+	return int(C.ipc_file_num_batches(f.ptr))
+}
+
+// ReadBatch returns the ith record batch
+func (f *File) ReadBatch(i int) (*carrow.RecordBatch, error) {
+	if f.ptr == nil {
+		return nil, fmt.Errorf("read from closed file")
+	}
+
+	r := C.ipc_file_read_batch(f.ptr, C.int(i))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return carrow.NewRecordBatchFromPtr(r.ptr), nil
+}
+
+// ReadAll reads all the record batches to a table
+func (f *File) ReadAll() (*carrow.Table, error) {
+	if f.ptr == nil {
+		return nil, fmt.Errorf("read from closed file")
+	}
+
+	r := C.ipc_file_read_all(f.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return carrow.NewTableFromPtr(r.ptr), nil
+}
+
+// Close closes the file
+func (f *File) Close() error {
+	if f.ptr == nil {
+		return nil
+	}
+
+	C.ipc_file_free(f.ptr)
+	f.ptr = nil
+	return nil
+}
@@ -0,0 +1,46 @@
+package ipc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/353solutions/carrow"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	require := require.New(t)
+	dir, err := ioutil.TempDir("", "carrow-ipc")
+	require.NoError(err, "temp dir")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "table.arrow")
+
+	t1 := buildTable(require, 10)
+	t2 := buildTable(require, 5)
+	table, err := carrow.ConcatenateTables(t1, t2)
+	require.NoError(err, "concatenate")
+	require.NoError(WriteFile(path, table), "write file")
+
+	f, err := OpenFile(path)
+	require.NoError(err, "open file")
+	defer f.Close()
+
+	require.Equal(2, f.NumBatches(), "batches")
+	batch, err := f.ReadBatch(1)
+	require.NoError(err, "read batch")
+	require.Equal(5, batch.NumRows(), "batch rows")
+
+	_, err = f.ReadBatch(2)
+	require.Error(err, "batch out of range")
+
+	out, err := f.ReadAll()
+	require.NoError(err, "read all")
+	ok, err := out.Equal(table)
+	require.NoError(err, "equal")
+	require.True(ok, "round trip")
+
+	_, err = OpenFile(filepath.Join(dir, "no-such-file"))
+	require.Error(err, "missing file")
+}
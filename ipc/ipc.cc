@@ -0,0 +1,384 @@
+#include <arrow/api.h>
+#include <arrow/io/api.h>
+#include <arrow/ipc/api.h>
+
+#include <cstdlib>
+#include <cstring>
+#include <memory>
+#include <string>
+#include <vector>
+
+#include "_cgo_export.h"
+#include "ipc.h"
+
+#define IPC_RETURN_IF_ERROR(status)                                            \
+  do {                                                                         \
+    if (!status.ok()) {                                                        \
+      return result_t{strdup(status.message().c_str()), nullptr};              \
+    }                                                                          \
+  } while (false)
+
+namespace {
+
+// Same layout as the ones in carrow.cc
+struct Schema {
+  std::shared_ptr<arrow::Schema> ptr;
+};
+
+struct Table {
+  std::shared_ptr<arrow::Table> ptr;
+};
+
+struct RecordBatch {
+  std::shared_ptr<arrow::RecordBatch> ptr;
+};
+
+// GoOutputStream writes to a Go io.Writer registered under id
+class GoOutputStream : public arrow::io::OutputStream {
+  long long id_;
+  int64_t position_ = 0;
+  bool closed_ = false;
+
+public:
+  GoOutputStream(long long id) : id_(id) {}
+
+  arrow::Status Close() override {
+    closed_ = true;
+    return arrow::Status::OK();
+  }
+
+  bool closed() const override { return closed_; }
+
+  arrow::Result<int64_t> Tell() const override { return position_; }
+
+  arrow::Status Write(const void *data, int64_t nbytes) override {
+    if (closed_) {
+      return arrow::Status::IOError("write to closed stream");
+    }
+
+    auto err = ipc_ostream_write(id_, (void *)data, nbytes);
+    if (err != nullptr) {
+      auto status = arrow::Status::IOError(err);
+      free(err);
+      return status;
+    }
+
+    position_ += nbytes;
+    return arrow::Status::OK();
+  }
+};
+
+// GoInputStream reads from a Go io.Reader registered under id
+class GoInputStream : public arrow::io::InputStream {
+  long long id_;
+  int64_t position_ = 0;
+  bool closed_ = false;
+
+public:
+  GoInputStream(long long id) : id_(id) {}
+
+  arrow::Status Close() override {
+    closed_ = true;
+    return arrow::Status::OK();
+  }
+
+  bool closed() const override { return closed_; }
+
+  arrow::Result<int64_t> Tell() const override { return position_; }
+
+  // Reads less than nbytes only at end of stream
+  arrow::Result<int64_t> Read(int64_t nbytes, void *out) override {
+    if (closed_) {
+      return arrow::Status::IOError("read from closed stream");
+    }
+
+    char *err = nullptr;
+    auto n = ipc_istream_read(id_, out, nbytes, &err);
+    if (err != nullptr) {
+      auto status = arrow::Status::IOError(err);
+      free(err);
+      return status;
+    }
+
+    position_ += n;
+    return n;
+  }
+
+  arrow::Result<std::shared_ptr<arrow::Buffer>> Read(int64_t nbytes) override {
+    std::string data(nbytes, '\0');
+    auto res = Read(nbytes, &data[0]);
+    if (!res.ok()) {
+      return res.status();
+    }
+
+    data.resize(res.ValueOrDie());
+    return arrow::Buffer::FromString(std::move(data));
+  }
+};
+
+struct Writer {
+  std::shared_ptr<arrow::io::OutputStream> stream;
+  std::shared_ptr<arrow::ipc::RecordBatchWriter> writer;
+};
+
+struct Reader {
+  std::shared_ptr<arrow::io::InputStream> stream;
+  std::shared_ptr<arrow::RecordBatchReader> reader;
+};
+
+struct File {
+  std::shared_ptr<arrow::io::MemoryMappedFile> file;
+  std::shared_ptr<arrow::ipc::RecordBatchFileReader> reader;
+};
+
+} // namespace
+
+result_t ipc_stream_writer_new(long long id, void *schema) {
+  auto sp = (Schema *)schema;
+  if (sp == nullptr) {
+    return result_t{strdup("null schema"), nullptr};
+  }
+
+  auto wp = new Writer;
+  wp->stream = std::make_shared<GoOutputStream>(id);
+  auto status = arrow::ipc::RecordBatchStreamWriter::Open(
+      wp->stream.get(), sp->ptr, &wp->writer);
+  if (!status.ok()) {
+    delete wp;
+  }
+  IPC_RETURN_IF_ERROR(status);
+
+  return result_t{nullptr, wp};
+}
+
+result_t ipc_writer_write_table(void *vp, void *tp) {
+  auto wp = (Writer *)vp;
+  auto table = (Table *)tp;
+  if ((wp == nullptr) || (table == nullptr)) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto status = wp->writer->WriteTable(*table->ptr);
+  IPC_RETURN_IF_ERROR(status);
+  return result_t{nullptr, nullptr};
+}
+
+result_t ipc_writer_write_batch(void *vp, void *bp) {
+  auto wp = (Writer *)vp;
+  auto batch = (RecordBatch *)bp;
+  if ((wp == nullptr) || (batch == nullptr)) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto status = wp->writer->WriteRecordBatch(*batch->ptr);
+  IPC_RETURN_IF_ERROR(status);
+  return result_t{nullptr, nullptr};
+}
+
+// Writes the end of stream marker, the Go writer is not closed
+result_t ipc_writer_close(void *vp) {
+  auto wp = (Writer *)vp;
+  if (wp == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto status = wp->writer->Close();
+  IPC_RETURN_IF_ERROR(status);
+  status = wp->stream->Close();
+  IPC_RETURN_IF_ERROR(status);
+  return result_t{nullptr, nullptr};
+}
+
+void ipc_writer_free(void *vp) {
+  if (vp == nullptr) {
+    return;
+  }
+
+  delete (Writer *)vp;
+}
+
+result_t ipc_stream_reader_new(long long id) {
+  auto rp = new Reader;
+  rp->stream = std::make_shared<GoInputStream>(id);
+  auto status =
+      arrow::ipc::RecordBatchStreamReader::Open(rp->stream, &rp->reader);
+  if (!status.ok()) {
+    delete rp;
+  }
+  IPC_RETURN_IF_ERROR(status);
+
+  return result_t{nullptr, rp};
+}
+
+void *ipc_reader_schema(void *vp) {
+  auto rp = (Reader *)vp;
+  if (rp == nullptr) {
+    return nullptr;
+  }
+
+  auto schema = new Schema;
+  schema->ptr = rp->reader->schema();
+  return schema;
+}
+
+// ptr is null at end of stream
+result_t ipc_reader_next(void *vp) {
+  auto rp = (Reader *)vp;
+  if (rp == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::shared_ptr<arrow::RecordBatch> batch;
+  auto status = rp->reader->ReadNext(&batch);
+  IPC_RETURN_IF_ERROR(status);
+  if (batch == nullptr) {
+    return result_t{nullptr, nullptr};
+  }
+
+  auto wrapper = new RecordBatch;
+  wrapper->ptr = batch;
+  return result_t{nullptr, wrapper};
+}
+
+// Reads the remaining batches into a table
+result_t ipc_reader_read_all(void *vp) {
+  auto rp = (Reader *)vp;
+  if (rp == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::vector<std::shared_ptr<arrow::RecordBatch>> batches;
+  auto status = rp->reader->ReadAll(&batches);
+  IPC_RETURN_IF_ERROR(status);
+
+  std::shared_ptr<arrow::Table> table;
+  status = arrow::Table::FromRecordBatches(rp->reader->schema(), batches,
+                                           &table);
+  IPC_RETURN_IF_ERROR(status);
+
+  auto wrapper = new Table;
+  wrapper->ptr = table;
+  return result_t{nullptr, wrapper};
+}
+
+void ipc_reader_free(void *vp) {
+  if (vp == nullptr) {
+    return;
+  }
+
+  delete (Reader *)vp;
+}
+
+result_t ipc_write_file(const char *path, void *tp) {
+  auto table = (Table *)tp;
+  if (table == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  auto res = arrow::io::FileOutputStream::Open(path);
+  IPC_RETURN_IF_ERROR(res.status());
+  auto stream = res.ValueOrDie();
+
+  std::shared_ptr<arrow::ipc::RecordBatchWriter> writer;
+  auto status = arrow::ipc::RecordBatchFileWriter::Open(
+      stream.get(), table->ptr->schema(), &writer);
+  IPC_RETURN_IF_ERROR(status);
+  status = writer->WriteTable(*table->ptr);
+  IPC_RETURN_IF_ERROR(status);
+  status = writer->Close();
+  IPC_RETURN_IF_ERROR(status);
+  status = stream->Close();
+  IPC_RETURN_IF_ERROR(status);
+
+  return result_t{nullptr, nullptr};
+}
+
+// The file is memory mapped, batches are read on demand
+result_t ipc_file_open(const char *path) {
+  auto res =
+      arrow::io::MemoryMappedFile::Open(path, arrow::io::FileMode::READ);
+  IPC_RETURN_IF_ERROR(res.status());
+
+  auto fp = new File;
+  fp->file = res.ValueOrDie();
+  auto status = arrow::ipc::RecordBatchFileReader::Open(fp->file, &fp->reader);
+  if (!status.ok()) {
+    delete fp;
+  }
+  IPC_RETURN_IF_ERROR(status);
+
+  return result_t{nullptr, fp};
+}
+
+void *ipc_file_schema(void *vp) {
+  auto fp = (File *)vp;
+  if (fp == nullptr) {
+    return nullptr;
+  }
+
+  auto schema = new Schema;
+  schema->ptr = fp->reader->schema();
+  return schema;
+}
+
+int ipc_file_num_batches(void *vp) {
+  auto fp = (File *)vp;
+  if (fp == nullptr) {
+    return -1;
+  }
+
+  return fp->reader->num_record_batches();
+}
+
+result_t ipc_file_read_batch(void *vp, int i) {
+  auto fp = (File *)vp;
+  if (fp == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  if ((i < 0) || (i >= fp->reader->num_record_batches())) {
+    return result_t{strdup("batch index out of range"), nullptr};
+  }
+
+  std::shared_ptr<arrow::RecordBatch> batch;
+  auto status = fp->reader->ReadRecordBatch(i, &batch);
+  IPC_RETURN_IF_ERROR(status);
+
+  auto wrapper = new RecordBatch;
+  wrapper->ptr = batch;
+  return result_t{nullptr, wrapper};
+}
+
+result_t ipc_file_read_all(void *vp) {
+  auto fp = (File *)vp;
+  if (fp == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  std::vector<std::shared_ptr<arrow::RecordBatch>> batches;
+  for (int i = 0; i < fp->reader->num_record_batches(); i++) {
+    std::shared_ptr<arrow::RecordBatch> batch;
+    auto status = fp->reader->ReadRecordBatch(i, &batch);
+    IPC_RETURN_IF_ERROR(status);
+    batches.push_back(batch);
+  }
+
+  std::shared_ptr<arrow::Table> table;
+  auto status = arrow::Table::FromRecordBatches(fp->reader->schema(), batches,
+                                                &table);
+  IPC_RETURN_IF_ERROR(status);
+
+  auto wrapper = new Table;
+  wrapper->ptr = table;
+  return result_t{nullptr, wrapper};
+}
+
+void ipc_file_free(void *vp) {
+  auto fp = (File *)vp;
+  if (fp == nullptr) {
+    return;
+  }
+
+  fp->file->Close();
+  delete fp;
+}
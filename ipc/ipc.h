@@ -0,0 +1,40 @@
+#ifndef CARROW_IPC_H
+#define CARROW_IPC_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+#include <stdint.h>
+
+typedef struct {
+  const char *err;
+  void *ptr;
+  int64_t i;
+} result_t;
+
+result_t ipc_stream_writer_new(long long id, void *schema);
+result_t ipc_writer_write_table(void *vp, void *tp);
+result_t ipc_writer_write_batch(void *vp, void *bp);
+result_t ipc_writer_close(void *vp);
+void ipc_writer_free(void *vp);
+
+result_t ipc_stream_reader_new(long long id);
+void *ipc_reader_schema(void *vp);
+result_t ipc_reader_next(void *vp);
+result_t ipc_reader_read_all(void *vp);
+void ipc_reader_free(void *vp);
+
+result_t ipc_write_file(const char *path, void *tp);
+result_t ipc_file_open(const char *path);
+void *ipc_file_schema(void *vp);
+int ipc_file_num_batches(void *vp);
+result_t ipc_file_read_batch(void *vp, int i);
+result_t ipc_file_read_all(void *vp);
+void ipc_file_free(void *vp);
+
+#ifdef __cplusplus
+}
+#endif // extern "C"
+
+#endif // CARROW_IPC_H
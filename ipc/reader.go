@@ -0,0 +1,93 @@
+package ipc
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	"github.com/353solutions/carrow"
+)
+
+/*
+#cgo pkg-config: arrow plasma
+
+#include "ipc.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// StreamReader reads record batches in Arrow IPC stream format
+type StreamReader struct {
+	ptr unsafe.Pointer
+	id  int64
+}
+
+// NewStreamReader returns a StreamReader reading from r, the stream schema is
+// read immediately
+// r can be the output of pyarrow.RecordBatchStreamWriter
+func NewStreamReader(r io.Reader) (*StreamReader, error) {
+	id := reg.alloc(r)
+	res := C.ipc_stream_reader_new(C.longlong(id))
+	if err := errFromResult(res); err != nil {
+		reg.release(id)
+		return nil, err
+	}
+
+	return &StreamReader{res.ptr, id}, nil
+}
+
+// Schema returns the stream schema
+func (r *StreamReader) Schema() *carrow.Schema {
+	ptr := C.ipc_reader_schema(r.ptr)
+	if ptr == nil {
+		return nil
+	}
+
+	return carrow.NewSchemaFromPtr(ptr)
+}
+
+// Next returns the next record batch in the stream, at end of stream it
+// returns io.EOF
+func (r *StreamReader) Next() (*carrow.RecordBatch, error) {
+	if r.ptr == nil {
+		return nil, fmt.Errorf("read from closed reader")
+	}
+
+	res := C.ipc_reader_next(r.ptr)
+	if err := errFromResult(res); err != nil {
+		return nil, err
+	}
+
+	if res.ptr == nil {
+		return nil, io.EOF
+	}
+
+	return carrow.NewRecordBatchFromPtr(res.ptr), nil
+}
+
+// ReadAll reads all remaining batches in the stream to a table
+func (r *StreamReader) ReadAll() (*carrow.Table, error) {
+	if r.ptr == nil {
+		return nil, fmt.Errorf("read from closed reader")
+	}
+
+	res := C.ipc_reader_read_all(r.ptr)
+	if err := errFromResult(res); err != nil {
+		return nil, err
+	}
+
+	return carrow.NewTableFromPtr(res.ptr), nil
+}
+
+// Close releases the reader resources, it does not close the underlying
+// io.Reader
+func (r *StreamReader) Close() error {
+	if r.ptr == nil {
+		return nil
+	}
+
+	C.ipc_reader_free(r.ptr)
+	r.ptr = nil
+	reg.release(r.id)
+	return nil
+}
@@ -0,0 +1,53 @@
+package ipc
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamReader(t *testing.T) {
+	require := require.New(t)
+	table := buildTable(require, 10)
+
+	var buf bytes.Buffer
+	w, err := NewStreamWriter(&buf, table.Schema())
+	require.NoError(err, "new writer")
+	batches, err := table.Batches(4)
+	require.NoError(err, "batches")
+	for _, batch := range batches {
+		require.NoError(w.WriteBatch(batch), "write batch")
+	}
+	require.NoError(w.Close(), "close writer")
+
+	data := buf.Bytes()
+	r, err := NewStreamReader(bytes.NewReader(data))
+	require.NoError(err, "new reader")
+	defer r.Close()
+	require.Contains(r.Schema().String(), "s: string", "schema")
+
+	batch, err := r.Next()
+	require.NoError(err, "next")
+	require.Equal(4, batch.NumRows(), "batch rows")
+
+	rest, err := r.ReadAll()
+	require.NoError(err, "read all")
+	require.Equal(6, rest.NumRows(), "rest rows")
+
+	_, err = r.Next()
+	require.Equal(io.EOF, err, "end of stream")
+
+	r2, err := NewStreamReader(bytes.NewReader(data))
+	require.NoError(err, "new reader")
+	defer r2.Close()
+	out, err := r2.ReadAll()
+	require.NoError(err, "read all")
+	ok, err := out.Equal(table)
+	require.NoError(err, "equal")
+	require.True(ok, "round trip")
+
+	_, err = NewStreamReader(bytes.NewReader([]byte("not arrow")))
+	require.Error(err, "bad stream")
+}
@@ -0,0 +1,70 @@
+package ipc
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	"github.com/353solutions/carrow"
+)
+
+/*
+#cgo pkg-config: arrow plasma
+
+#include "ipc.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// StreamWriter writes record batches in Arrow IPC stream format
+type StreamWriter struct {
+	ptr unsafe.Pointer
+	id  int64
+}
+
+// NewStreamWriter returns a StreamWriter writing batches matching schema to w
+// The stream can be read by pyarrow.ipc.open_stream
+func NewStreamWriter(w io.Writer, schema *carrow.Schema) (*StreamWriter, error) {
+	id := reg.alloc(w)
+	r := C.ipc_stream_writer_new(C.longlong(id), schema.Ptr())
+	if err := errFromResult(r); err != nil {
+		reg.release(id)
+		return nil, err
+	}
+
+	return &StreamWriter{r.ptr, id}, nil
+}
+
+// WriteBatch writes a record batch to the stream
+func (w *StreamWriter) WriteBatch(batch *carrow.RecordBatch) error {
+	if w.ptr == nil {
+		return fmt.Errorf("write to closed writer")
+	}
+
+	r := C.ipc_writer_write_batch(w.ptr, batch.Ptr())
+	return errFromResult(r)
+}
+
+// WriteTable writes all the table batches to the stream
+func (w *StreamWriter) WriteTable(table *carrow.Table) error {
+	if w.ptr == nil {
+		return fmt.Errorf("write to closed writer")
+	}
+
+	r := C.ipc_writer_write_table(w.ptr, table.Ptr())
+	return errFromResult(r)
+}
+
+// Close writes the end of stream marker, it does not close the underlying
+// io.Writer
+func (w *StreamWriter) Close() error {
+	if w.ptr == nil {
+		return nil
+	}
+
+	r := C.ipc_writer_close(w.ptr)
+	C.ipc_writer_free(w.ptr)
+	w.ptr = nil
+	reg.release(w.id)
+	return errFromResult(r)
+}
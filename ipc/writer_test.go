@@ -0,0 +1,51 @@
+package ipc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/353solutions/carrow"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTable(require *require.Assertions, size int) *carrow.Table {
+	ib := carrow.NewInteger64ArrayBuilder()
+	sb := carrow.NewStringArrayBuilder()
+	for i := 0; i < size; i++ {
+		require.NoError(ib.Append(int64(i)), "append int")
+		require.NoError(sb.Append(string(rune('a'+i%26))), "append string")
+	}
+	ints, err := ib.Finish()
+	require.NoError(err, "finish ints")
+	strs, err := sb.Finish()
+	require.NoError(err, "finish strings")
+
+	intField, err := carrow.NewField("i", carrow.Integer64Type)
+	require.NoError(err, "int field")
+	strField, err := carrow.NewField("s", carrow.StringType)
+	require.NoError(err, "string field")
+	schema, err := carrow.NewSchema([]*carrow.Field{intField, strField})
+	require.NoError(err, "schema")
+
+	table, err := carrow.NewTableFromArrays(schema, []*carrow.Array{ints, strs})
+	require.NoError(err, "table")
+	return table
+}
+
+func TestStreamWriter(t *testing.T) {
+	require := require.New(t)
+	table := buildTable(require, 10)
+
+	var buf bytes.Buffer
+	w, err := NewStreamWriter(&buf, table.Schema())
+	require.NoError(err, "new writer")
+	require.NoError(w.WriteTable(table), "write table")
+
+	batches, err := table.Batches(4)
+	require.NoError(err, "batches")
+	require.NoError(w.WriteBatch(batches[0]), "write batch")
+	require.NoError(w.Close(), "close")
+	require.True(buf.Len() > 0, "empty output")
+
+	require.Error(w.WriteTable(table), "write after close")
+}
@@ -0,0 +1,114 @@
+package carrow
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+/*
+#include "carrow.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// JoinType is the type of join
+type JoinType int
+
+// Join types
+const (
+	InnerJoin JoinType = C.JOIN_INNER
+	LeftJoin  JoinType = C.JOIN_LEFT
+	OuterJoin JoinType = C.JOIN_OUTER
+	SemiJoin  JoinType = C.JOIN_SEMI // Left rows with a match, left columns only
+	AntiJoin  JoinType = C.JOIN_ANTI // Left rows without a match, left columns only
+)
+
+func (t JoinType) String() string {
+	switch t {
+	case InnerJoin:
+		return "inner"
+	case LeftJoin:
+		return "left"
+	case OuterJoin:
+		return "outer"
+	case SemiJoin:
+		return "semi"
+	case AntiJoin:
+		return "anti"
+	}
+
+	return "<unknown>"
+}
+
+// JoinOptions are options for Join
+type JoinOptions struct {
+	LeftKeys  []string
+	RightKeys []string // If empty, LeftKeys are used
+	Type      JoinType // Default to InnerJoin
+	// Suffixes added to non key columns that appear in both tables, default
+	// to "_left" & "_right"
+	Suffixes [2]string
+}
+
+// Join returns a new table joining rows of left and right with equal keys
+// The output has the left columns followed by the right columns. Right keys
+// with the same name as the matching left key are merged into the left one.
+// Null keys never match.
+func Join(left, right *Table, opts JoinOptions) (*Table, error) {
+	rightKeys := opts.RightKeys
+	if len(rightKeys) == 0 {
+		rightKeys = opts.LeftKeys
+	}
+
+	if len(opts.LeftKeys) == 0 {
+		return nil, fmt.Errorf("no join keys")
+	}
+
+	if len(opts.LeftKeys) != len(rightKeys) {
+		return nil, fmt.Errorf("%d left keys but %d right keys", len(opts.LeftKeys), len(rightKeys))
+	}
+
+	switch opts.Type {
+	case InnerJoin, LeftJoin, OuterJoin, SemiJoin, AntiJoin:
+	default:
+		return nil, fmt.Errorf("unknown join type: %d", opts.Type)
+	}
+
+	lkeys := make([]C.int, 0, len(rightKeys))
+	rkeys := make([]C.int, 0, len(rightKeys))
+	for n, name := range opts.LeftKeys {
+		i, err := left.columnIndex(name)
+		if err != nil {
+			return nil, fmt.Errorf("left: %w", err)
+		}
+		lkeys = append(lkeys, C.int(i))
+
+		i, err = right.columnIndex(rightKeys[n])
+		if err != nil {
+			return nil, fmt.Errorf("right: %w", err)
+		}
+		rkeys = append(rkeys, C.int(i))
+	}
+
+	suffixes := opts.Suffixes
+	if suffixes[0] == "" {
+		suffixes[0] = "_left"
+	}
+	if suffixes[1] == "" {
+		suffixes[1] = "_right"
+	}
+	lsuffix, rsuffix := C.CString(suffixes[0]), C.CString(suffixes[1])
+	defer C.free(unsafe.Pointer(lsuffix))
+	defer C.free(unsafe.Pointer(rsuffix))
+
+	r := C.table_join(
+		left.ptr, right.ptr,
+		&lkeys[0], &rkeys[0], C.size_t(len(lkeys)),
+		C.int(opts.Type), lsuffix, rsuffix,
+	)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Table{r.ptr}, nil
+}
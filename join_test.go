@@ -0,0 +1,143 @@
+package carrow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func buildJoinTable(require *require.Assertions, names []string, arrs []*Array) *Table {
+	fields := make([]*Field, 0, len(names))
+	for i, name := range names {
+		fld, err := NewField(name, arrs[i].DType())
+		require.NoErrorf(err, "field %s", name)
+		fields = append(fields, fld)
+	}
+
+	schema, err := NewSchema(fields)
+	require.NoError(err, "schema")
+	table, err := NewTableFromArrays(schema, arrs)
+	require.NoError(err, "table")
+	return table
+}
+
+func TestJoin(t *testing.T) {
+	require := require.New(t)
+	events := buildJoinTable(require,
+		[]string{"id", "value"},
+		[]*Array{
+			buildIntArray(require, 1, 2, 3, 1),
+			buildIntArray(require, 10, 20, 30, 40),
+		},
+	)
+	users := buildJoinTable(require,
+		[]string{"id", "value", "name"},
+		[]*Array{
+			buildIntArray(require, 1, 2, 4),
+			buildIntArray(require, 100, 200, 400),
+			buildStringArray(require, "a", "b", "d"),
+		},
+	)
+
+	opts := JoinOptions{LeftKeys: []string{"id"}}
+	out, err := Join(events, users, opts)
+	require.NoError(err, "inner join")
+	require.Equal(3, out.NumRows(), "inner rows")
+	names, err := out.ColumnNames()
+	require.NoError(err, "ColumnNames")
+	require.Equal([]string{"id", "value_left", "value_right", "name"}, names, "inner names")
+
+	opts.Type = LeftJoin
+	out, err = Join(events, users, opts)
+	require.NoError(err, "left join")
+	require.Equal(4, out.NumRows(), "left rows")
+
+	opts.Type = OuterJoin
+	out, err = Join(events, users, opts)
+	require.NoError(err, "outer join")
+	require.Equal(5, out.NumRows(), "outer rows")
+	ids, err := out.ColumnByName("id")
+	require.NoError(err, "id column")
+	id, err := ids.Int64At(4)
+	require.NoError(err, "Int64At(4)")
+	require.Equal(int64(4), id, "right only id")
+
+	opts.Type = SemiJoin
+	out, err = Join(events, users, opts)
+	require.NoError(err, "semi join")
+	require.Equal(3, out.NumRows(), "semi rows")
+	require.Equal(2, out.NumCols(), "semi columns")
+
+	opts.Type = AntiJoin
+	out, err = Join(events, users, opts)
+	require.NoError(err, "anti join")
+	require.Equal(1, out.NumRows(), "anti rows")
+
+	_, err = Join(events, users, JoinOptions{LeftKeys: []string{"id"}, RightKeys: []string{"name"}})
+	require.Error(err, "key type mismatch")
+
+	_, err = Join(events, users, JoinOptions{LeftKeys: []string{"id"}, Type: JoinType(17)})
+	require.Error(err, "unknown join type")
+}
+
+func buildNullableStrings(require *require.Assertions, values ...*string) *Array {
+	b := NewStringArrayBuilder()
+	for _, v := range values {
+		if v == nil {
+			require.NoError(b.AppendNull(), "append null")
+			continue
+		}
+		require.NoErrorf(b.Append(*v), "append %q", *v)
+	}
+
+	arr, err := b.Finish()
+	require.NoError(err, "finish")
+	return arr
+}
+
+func TestJoinStringKeys(t *testing.T) {
+	require := require.New(t)
+	str := func(s string) *string { return &s }
+	left := buildJoinTable(require,
+		[]string{"key", "lval"},
+		[]*Array{
+			buildNullableStrings(require, str("a"), nil, str("b"), str("c")),
+			buildIntArray(require, 1, 2, 3, 4),
+		},
+	)
+	right := buildJoinTable(require,
+		[]string{"key", "rval"},
+		[]*Array{
+			buildNullableStrings(require, str("b"), nil, str("a")),
+			buildIntArray(require, 20, 30, 10),
+		},
+	)
+
+	opts := JoinOptions{LeftKeys: []string{"key"}}
+	out, err := Join(left, right, opts)
+	require.NoError(err, "inner join")
+	require.Equal(2, out.NumRows(), "inner rows (null keys never match)")
+	names, err := out.ColumnNames()
+	require.NoError(err, "ColumnNames")
+	require.Equal([]string{"key", "lval", "rval"}, names, "inner names")
+	rvals, err := out.ColumnByName("rval")
+	require.NoError(err, "rval column")
+	sum, err := rvals.Sum()
+	require.NoError(err, "sum")
+	require.Equal(int64(30), sum, "inner matched values")
+
+	opts.Type = LeftJoin
+	out, err = Join(left, right, opts)
+	require.NoError(err, "left join")
+	require.Equal(4, out.NumRows(), "left rows")
+	rvals, err = out.ColumnByName("rval")
+	require.NoError(err, "rval column")
+	nulls, err := rvals.Count(CountNulls)
+	require.NoError(err, "count nulls")
+	require.Equal(2, nulls, "unmatched rows (null and c)")
+	keys, err := out.ColumnByName("key")
+	require.NoError(err, "key column")
+	nulls, err = keys.Count(CountNulls)
+	require.NoError(err, "count null keys")
+	require.Equal(1, nulls, "null left key kept")
+}
@@ -0,0 +1,122 @@
+#include <arrow/api.h>
+#include <arrow/io/api.h>
+#include <arrow/json/api.h>
+
+#include <cstdlib>
+#include <cstring>
+#include <memory>
+#include <string>
+
+#include "_cgo_export.h"
+#include "json.h"
+
+#define JSON_RETURN_IF_ERROR(status)                                           \
+  do {                                                                         \
+    if (!status.ok()) {                                                        \
+      return result_t{strdup(status.message().c_str()), nullptr};              \
+    }                                                                          \
+  } while (false)
+
+namespace {
+
+// Same layout as the ones in carrow.cc
+struct Schema {
+  std::shared_ptr<arrow::Schema> ptr;
+};
+
+struct Table {
+  std::shared_ptr<arrow::Table> ptr;
+};
+
+// GoInputStream reads from a Go io.Reader registered under id
+class GoInputStream : public arrow::io::InputStream {
+  long long id_;
+  int64_t position_ = 0;
+  bool closed_ = false;
+
+public:
+  GoInputStream(long long id) : id_(id) {}
+
+  arrow::Status Close() override {
+    closed_ = true;
+    return arrow::Status::OK();
+  }
+
+  bool closed() const override { return closed_; }
+
+  arrow::Result<int64_t> Tell() const override { return position_; }
+
+  // Reads less than nbytes only at end of stream
+  arrow::Result<int64_t> Read(int64_t nbytes, void *out) override {
+    if (closed_) {
+      return arrow::Status::IOError("read from closed stream");
+    }
+
+    char *err = nullptr;
+    auto n = json_istream_read(id_, out, nbytes, &err);
+    if (err != nullptr) {
+      auto status = arrow::Status::IOError(err);
+      free(err);
+      return status;
+    }
+
+    position_ += n;
+    return n;
+  }
+
+  arrow::Result<std::shared_ptr<arrow::Buffer>> Read(int64_t nbytes) override {
+    std::string data(nbytes, '\0');
+    auto res = Read(nbytes, &data[0]);
+    if (!res.ok()) {
+      return res.status();
+    }
+
+    data.resize(res.ValueOrDie());
+    return arrow::Buffer::FromString(std::move(data));
+  }
+};
+
+} // namespace
+
+result_t json_read(long long id, void *schema, int64_t block_size,
+                   int unexpected_field_behavior) {
+  auto read_options = arrow::json::ReadOptions::Defaults();
+  if (block_size > 0) {
+    read_options.block_size = block_size;
+  }
+
+  auto parse_options = arrow::json::ParseOptions::Defaults();
+  if (schema != nullptr) {
+    parse_options.explicit_schema = ((Schema *)schema)->ptr;
+  }
+
+  switch (unexpected_field_behavior) {
+  case UNEXPECTED_INFER_TYPE:
+    parse_options.unexpected_field_behavior =
+        arrow::json::UnexpectedFieldBehavior::InferType;
+    break;
+  case UNEXPECTED_IGNORE:
+    parse_options.unexpected_field_behavior =
+        arrow::json::UnexpectedFieldBehavior::Ignore;
+    break;
+  case UNEXPECTED_ERROR:
+    parse_options.unexpected_field_behavior =
+        arrow::json::UnexpectedFieldBehavior::Error;
+    break;
+  default:
+    return result_t{strdup("unknown unexpected field behavior"), nullptr};
+  }
+
+  std::shared_ptr<arrow::io::InputStream> input =
+      std::make_shared<GoInputStream>(id);
+  auto reader = arrow::json::TableReader::Make(
+      arrow::default_memory_pool(), input, read_options, parse_options);
+  JSON_RETURN_IF_ERROR(reader.status());
+
+  auto table = reader.ValueOrDie()->Read();
+  JSON_RETURN_IF_ERROR(table.status());
+
+  auto wrapper = new Table;
+  wrapper->ptr = table.ValueOrDie();
+  return result_t{nullptr, wrapper};
+}
@@ -0,0 +1,90 @@
+// Package json reads newline delimited JSON (JSON lines) to tables
+package json
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	"github.com/353solutions/carrow"
+	"github.com/353solutions/carrow/internal/bridge"
+)
+
+/*
+#cgo pkg-config: arrow plasma
+
+#include "json.h"
+#include <stdlib.h>
+*/
+import "C"
+
+var (
+	reg = bridge.NewRegistry()
+)
+
+//export json_istream_read
+func json_istream_read(id C.longlong, data unsafe.Pointer, size C.longlong, cErr **C.char) C.longlong {
+	// Fill data with size bytes, return less only at end of stream
+	r, ok := reg.Get(int64(id)).(io.Reader)
+	if !ok {
+		*cErr = C.CString(fmt.Sprintf("%d: unknown reader id", id))
+		return 0
+	}
+
+	buf := bridge.Bytes(data, int64(size))
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		*cErr = C.CString(err.Error())
+	}
+
+	return C.longlong(n)
+}
+
+// UnexpectedFieldBehavior is how to handle fields not in Options.Schema
+type UnexpectedFieldBehavior int
+
+// Unexpected field behaviors
+const (
+	InferType UnexpectedFieldBehavior = C.UNEXPECTED_INFER_TYPE // Add a column with inferred type
+	Ignore    UnexpectedFieldBehavior = C.UNEXPECTED_IGNORE
+	Error     UnexpectedFieldBehavior = C.UNEXPECTED_ERROR
+)
+
+func (b UnexpectedFieldBehavior) String() string {
+	switch b {
+	case InferType:
+		return "infer_type"
+	case Ignore:
+		return "ignore"
+	case Error:
+		return "error"
+	}
+
+	return "<unknown>"
+}
+
+// Options are options for Read
+type Options struct {
+	Schema                  *carrow.Schema // If nil, schema is inferred
+	BlockSize               int            // Bytes to process at a time, 0 for Arrow default
+	UnexpectedFieldBehavior UnexpectedFieldBehavior
+}
+
+// Read reads JSON lines from r, one row per line
+// Nested objects are read as StructType columns and arrays as ListType columns
+func Read(r io.Reader, opts Options) (*carrow.Table, error) {
+	id := reg.Alloc(r)
+	defer reg.Release(id)
+
+	var schema unsafe.Pointer
+	if opts.Schema != nil {
+		schema = opts.Schema.Ptr()
+	}
+
+	res := C.json_read(C.longlong(id), schema, C.int64_t(opts.BlockSize), C.int(opts.UnexpectedFieldBehavior))
+	if err := bridge.Error(unsafe.Pointer(res.err)); err != nil {
+		return nil, err
+	}
+
+	return carrow.NewTableFromPtr(res.ptr), nil
+}
@@ -0,0 +1,28 @@
+#ifndef CARROW_JSON_H
+#define CARROW_JSON_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+#include <stdint.h>
+
+typedef struct {
+  const char *err;
+  void *ptr;
+  int64_t i;
+} result_t;
+
+#define UNEXPECTED_INFER_TYPE 0
+#define UNEXPECTED_IGNORE 1
+#define UNEXPECTED_ERROR 2
+
+// schema may be null, block_size <= 0 uses Arrow default
+result_t json_read(long long id, void *schema, int64_t block_size,
+                   int unexpected_field_behavior);
+
+#ifdef __cplusplus
+}
+#endif // extern "C"
+
+#endif // CARROW_JSON_H
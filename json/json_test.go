@@ -0,0 +1,57 @@
+package json
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/353solutions/carrow"
+	"github.com/stretchr/testify/require"
+)
+
+var events = `{"id": 1, "user": {"name": "bugs"}, "tags": ["a", "b"]}
+{"id": 2, "user": {"name": "daffy"}, "tags": []}
+{"id": 3, "user": null, "tags": ["c"], "extra": true}
+`
+
+func TestRead(t *testing.T) {
+	require := require.New(t)
+
+	table, err := Read(strings.NewReader(events), Options{})
+	require.NoError(err, "read")
+	require.Equal(3, table.NumRows(), "rows")
+	require.Equal(4, table.NumCols(), "columns")
+
+	user, err := table.ColumnByName("user")
+	require.NoError(err, "user column")
+	require.Equal(carrow.StructType, user.DType(), "user dtype")
+	names, err := user.StructField(0)
+	require.NoError(err, "user.name")
+	name, err := names.StringAt(1)
+	require.NoError(err, "StringAt(1)")
+	require.Equal("daffy", name, "name")
+
+	tags, err := table.ColumnByName("tags")
+	require.NoError(err, "tags column")
+	require.Equal(carrow.ListType, tags.DType(), "tags dtype")
+	row, err := tags.ListAt(0)
+	require.NoError(err, "ListAt(0)")
+	require.Equal(2, row.Length(), "tags length")
+}
+
+func TestReadSchema(t *testing.T) {
+	require := require.New(t)
+	fld, err := carrow.NewField("id", carrow.Integer64Type)
+	require.NoError(err, "field")
+	schema, err := carrow.NewSchema([]*carrow.Field{fld})
+	require.NoError(err, "schema")
+
+	table, err := Read(strings.NewReader(events), Options{Schema: schema, UnexpectedFieldBehavior: Ignore})
+	require.NoError(err, "read ignore")
+	require.Equal(1, table.NumCols(), "columns")
+
+	_, err = Read(strings.NewReader(events), Options{Schema: schema, UnexpectedFieldBehavior: Error})
+	require.Error(err, "unexpected field")
+
+	_, err = Read(strings.NewReader("{not json"), Options{})
+	require.Error(err, "bad json")
+}
@@ -0,0 +1,123 @@
+package parquet
+
+import (
+	"unsafe"
+)
+
+/*
+#cgo pkg-config: arrow parquet
+
+#include "parquet.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// FileMetadata is parquet file metadata
+type FileMetadata struct {
+	NumRows    int64
+	NumColumns int
+	RowGroups  []RowGroupMetadata
+}
+
+// NumRowGroups returns the number of row groups in the file
+func (m *FileMetadata) NumRowGroups() int {
+	return len(m.RowGroups)
+}
+
+// RowGroupMetadata is metadata of a row group
+type RowGroupMetadata struct {
+	NumRows       int64
+	TotalByteSize int64
+	Columns       []ColumnMetadata
+}
+
+// ColumnMetadata is metadata of a column chunk in a row group
+type ColumnMetadata struct {
+	Path       string // Dot separated path in the parquet schema
+	NumValues  int64
+	Statistics *Statistics // nil if the file has no statistics for the column
+}
+
+// Statistics are column chunk statistics
+// Min & Max are bool, int64, float64 or string. They are nil if HasMinMax is
+// false
+type Statistics struct {
+	NullCount     int64
+	DistinctCount int64
+	HasMinMax     bool
+	Min           interface{}
+	Max           interface{}
+}
+
+// Metadata reads metadata of parquet file at path, column data is not read
+func Metadata(path string) (*FileMetadata, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	r := C.parquet_metadata_open(cPath)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+	defer C.parquet_metadata_free(r.ptr)
+
+	meta := &FileMetadata{
+		NumRows:    int64(C.parquet_metadata_num_rows(r.ptr)),
+		NumColumns: int(C.parquet_metadata_num_columns(r.ptr)),
+	}
+
+	numGroups := int(C.parquet_metadata_num_row_groups(r.ptr))
+	for rg := 0; rg < numGroups; rg++ {
+		crg := C.int(rg)
+		group := RowGroupMetadata{
+			NumRows:       int64(C.parquet_row_group_num_rows(r.ptr, crg)),
+			TotalByteSize: int64(C.parquet_row_group_total_byte_size(r.ptr, crg)),
+		}
+
+		numCols := int(C.parquet_row_group_num_columns(r.ptr, crg))
+		for col := 0; col < numCols; col++ {
+			ccol := C.int(col)
+			cp := C.parquet_column_path(r.ptr, crg, ccol)
+			path := C.GoString(cp)
+			C.free(unsafe.Pointer(cp))
+
+			group.Columns = append(group.Columns, ColumnMetadata{
+				Path:       path,
+				NumValues:  int64(C.parquet_column_num_values(r.ptr, crg, ccol)),
+				Statistics: statsFromC(C.parquet_column_stats(r.ptr, crg, ccol)),
+			})
+		}
+		meta.RowGroups = append(meta.RowGroups, group)
+	}
+
+	return meta, nil
+}
+
+func statsFromC(cs C.stats_t) *Statistics {
+	defer func() {
+		C.free(unsafe.Pointer(cs.min_s))
+		C.free(unsafe.Pointer(cs.max_s))
+	}()
+
+	if cs.has_stats == 0 {
+		return nil
+	}
+
+	stats := &Statistics{
+		NullCount:     int64(cs.null_count),
+		DistinctCount: int64(cs.distinct_count),
+		HasMinMax:     cs.kind != C.STATS_NONE,
+	}
+
+	switch cs.kind {
+	case C.STATS_BOOL:
+		stats.Min, stats.Max = cs.min_i != 0, cs.max_i != 0
+	case C.STATS_INT:
+		stats.Min, stats.Max = int64(cs.min_i), int64(cs.max_i)
+	case C.STATS_FLOAT:
+		stats.Min, stats.Max = float64(cs.min_f), float64(cs.max_f)
+	case C.STATS_STRING:
+		stats.Min, stats.Max = C.GoString(cs.min_s), C.GoString(cs.max_s)
+	}
+
+	return stats
+}
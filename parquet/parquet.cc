@@ -0,0 +1,409 @@
+#include <arrow/api.h>
+#include <arrow/io/api.h>
+#include <parquet/arrow/reader.h>
+#include <parquet/arrow/schema.h>
+#include <parquet/arrow/writer.h>
+#include <parquet/exception.h>
+#include <parquet/file_reader.h>
+#include <parquet/metadata.h>
+#include <parquet/properties.h>
+#include <parquet/statistics.h>
+
+#include <cstdlib>
+#include <cstring>
+#include <memory>
+#include <sstream>
+#include <string>
+#include <vector>
+
+#include "_cgo_export.h"
+#include "parquet.h"
+
+#define PARQUET_RETURN_IF_ERROR(status)                                        \
+  do {                                                                         \
+    if (!status.ok()) {                                                        \
+      return result_t{strdup(status.message().c_str()), nullptr};              \
+    }                                                                          \
+  } while (false)
+
+namespace {
+
+// Same layout as the one in carrow.cc
+struct Table {
+  std::shared_ptr<arrow::Table> ptr;
+};
+
+struct Metadata {
+  std::shared_ptr<parquet::FileMetaData> ptr;
+};
+
+// GoReaderAt reads from a Go io.ReaderAt registered under id
+class GoReaderAt : public arrow::io::RandomAccessFile {
+  long long id_;
+  int64_t size_;
+  int64_t position_ = 0;
+  bool closed_ = false;
+
+public:
+  GoReaderAt(long long id, int64_t size) : id_(id), size_(size) {}
+
+  arrow::Status Close() override {
+    closed_ = true;
+    return arrow::Status::OK();
+  }
+
+  bool closed() const override { return closed_; }
+
+  arrow::Result<int64_t> Tell() const override { return position_; }
+
+  arrow::Result<int64_t> GetSize() override { return size_; }
+
+  arrow::Status Seek(int64_t position) override {
+    if ((position < 0) || (position > size_)) {
+      return arrow::Status::IOError("seek out of bounds");
+    }
+    position_ = position;
+    return arrow::Status::OK();
+  }
+
+  // Safe to call concurrently, io.ReaderAt allows parallel ReadAt calls
+  arrow::Result<int64_t> ReadAt(int64_t position, int64_t nbytes,
+                                void *out) override {
+    char *err = nullptr;
+    auto n = parquet_read_at(id_, out, nbytes, position, &err);
+    if (err != nullptr) {
+      auto status = arrow::Status::IOError(err);
+      free(err);
+      return status;
+    }
+    return n;
+  }
+
+  arrow::Result<std::shared_ptr<arrow::Buffer>>
+  ReadAt(int64_t position, int64_t nbytes) override {
+    std::string data(nbytes, '\0');
+    auto res = ReadAt(position, nbytes, &data[0]);
+    if (!res.ok()) {
+      return res.status();
+    }
+
+    data.resize(res.ValueOrDie());
+    return arrow::Buffer::FromString(std::move(data));
+  }
+
+  arrow::Result<int64_t> Read(int64_t nbytes, void *out) override {
+    auto res = ReadAt(position_, nbytes, out);
+    if (res.ok()) {
+      position_ += res.ValueOrDie();
+    }
+    return res;
+  }
+
+  arrow::Result<std::shared_ptr<arrow::Buffer>> Read(int64_t nbytes) override {
+    auto res = ReadAt(position_, nbytes);
+    if (res.ok()) {
+      position_ += res.ValueOrDie()->size();
+    }
+    return res;
+  }
+};
+
+// GoWriter writes to a Go io.Writer registered under id
+class GoWriter : public arrow::io::OutputStream {
+  long long id_;
+  int64_t position_ = 0;
+  bool closed_ = false;
+
+public:
+  GoWriter(long long id) : id_(id) {}
+
+  arrow::Status Close() override {
+    closed_ = true;
+    return arrow::Status::OK();
+  }
+
+  bool closed() const override { return closed_; }
+
+  arrow::Result<int64_t> Tell() const override { return position_; }
+
+  arrow::Status Write(const void *data, int64_t nbytes) override {
+    if (closed_) {
+      return arrow::Status::IOError("write to closed stream");
+    }
+
+    auto err = parquet_write(id_, (void *)data, nbytes);
+    if (err != nullptr) {
+      auto status = arrow::Status::IOError(err);
+      free(err);
+      return status;
+    }
+
+    position_ += nbytes;
+    return arrow::Status::OK();
+  }
+};
+
+// Collect parquet (leaf) column indices of field
+void leaf_indices(const parquet::arrow::SchemaField &field,
+                  std::vector<int> *out) {
+  if (field.children.empty()) {
+    out->push_back(field.column_index);
+    return;
+  }
+
+  for (auto &child : field.children) {
+    leaf_indices(child, out);
+  }
+}
+
+char *byte_array_string(const parquet::ByteArray &value) {
+  std::string s((const char *)value.ptr, value.len);
+  return strdup(s.c_str());
+}
+
+} // namespace
+
+result_t parquet_read_table(const char *path, long long id, int64_t size,
+                            char **columns, size_t ncols, int *row_groups,
+                            size_t nrow_groups, int use_threads) {
+  std::shared_ptr<arrow::io::RandomAccessFile> file;
+  if (path != nullptr) {
+    auto res = arrow::io::ReadableFile::Open(path);
+    PARQUET_RETURN_IF_ERROR(res.status());
+    file = res.ValueOrDie();
+  } else {
+    file = std::make_shared<GoReaderAt>(id, size);
+  }
+
+  std::unique_ptr<parquet::arrow::FileReader> reader;
+  auto status =
+      parquet::arrow::OpenFile(file, arrow::default_memory_pool(), &reader);
+  PARQUET_RETURN_IF_ERROR(status);
+  reader->set_use_threads(use_threads != 0);
+
+  std::shared_ptr<arrow::Schema> schema;
+  status = reader->GetSchema(&schema);
+  PARQUET_RETURN_IF_ERROR(status);
+
+  std::vector<int> indices;
+  if (ncols == 0) {
+    for (auto &field : reader->manifest().schema_fields) {
+      leaf_indices(field, &indices);
+    }
+  }
+  for (size_t i = 0; i < ncols; i++) {
+    auto n = schema->GetFieldIndex(columns[i]);
+    if (n == -1) {
+      std::ostringstream oss;
+      oss << "column " << columns[i] << " not found";
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+    leaf_indices(reader->manifest().schema_fields[n], &indices);
+  }
+
+  std::vector<int> groups;
+  for (size_t i = 0; i < nrow_groups; i++) {
+    if ((row_groups[i] < 0) || (row_groups[i] >= reader->num_row_groups())) {
+      std::ostringstream oss;
+      oss << "row group " << row_groups[i] << " out of range";
+      return result_t{strdup(oss.str().c_str()), nullptr};
+    }
+    groups.push_back(row_groups[i]);
+  }
+
+  std::shared_ptr<arrow::Table> table;
+  if (groups.empty()) {
+    status = reader->ReadTable(indices, &table);
+  } else {
+    status = reader->ReadRowGroups(groups, indices, &table);
+  }
+  PARQUET_RETURN_IF_ERROR(status);
+
+  auto wrapper = new Table;
+  wrapper->ptr = table;
+  return result_t{nullptr, wrapper};
+}
+
+result_t parquet_write_table(long long id, void *tp, int compression,
+                             int64_t row_group_size, int dictionary,
+                             int statistics) {
+  auto table = (Table *)tp;
+  if (table == nullptr) {
+    return result_t{strdup("null pointer"), nullptr};
+  }
+
+  parquet::WriterProperties::Builder builder;
+  switch (compression) {
+  case COMPRESSION_UNCOMPRESSED:
+    builder.compression(parquet::Compression::UNCOMPRESSED);
+    break;
+  case COMPRESSION_SNAPPY:
+    builder.compression(parquet::Compression::SNAPPY);
+    break;
+  case COMPRESSION_GZIP:
+    builder.compression(parquet::Compression::GZIP);
+    break;
+  case COMPRESSION_BROTLI:
+    builder.compression(parquet::Compression::BROTLI);
+    break;
+  case COMPRESSION_ZSTD:
+    builder.compression(parquet::Compression::ZSTD);
+    break;
+  case COMPRESSION_LZ4:
+    builder.compression(parquet::Compression::LZ4);
+    break;
+  default:
+    return result_t{strdup("unknown compression"), nullptr};
+  }
+
+  if (dictionary) {
+    builder.enable_dictionary();
+  } else {
+    builder.disable_dictionary();
+  }
+
+  if (statistics) {
+    builder.enable_statistics();
+  } else {
+    builder.disable_statistics();
+  }
+
+  if (row_group_size <= 0) {
+    row_group_size = parquet::DEFAULT_MAX_ROW_GROUP_LENGTH;
+  }
+
+  std::shared_ptr<arrow::io::OutputStream> sink =
+      std::make_shared<GoWriter>(id);
+  auto status = parquet::arrow::WriteTable(
+      *table->ptr, arrow::default_memory_pool(), sink, row_group_size,
+      builder.build());
+  PARQUET_RETURN_IF_ERROR(status);
+  status = sink->Close();
+  PARQUET_RETURN_IF_ERROR(status);
+
+  return result_t{nullptr, nullptr};
+}
+
+result_t parquet_metadata_open(const char *path) {
+  auto res = arrow::io::ReadableFile::Open(path);
+  PARQUET_RETURN_IF_ERROR(res.status());
+
+  // parquet reports errors with exceptions
+  try {
+    auto ptr = parquet::ReadMetaData(res.ValueOrDie());
+    auto meta = new Metadata;
+    meta->ptr = ptr;
+    return result_t{nullptr, meta};
+  } catch (const std::exception &e) {
+    return result_t{strdup(e.what()), nullptr};
+  }
+}
+
+int64_t parquet_metadata_num_rows(void *vp) {
+  return ((Metadata *)vp)->ptr->num_rows();
+}
+
+int parquet_metadata_num_columns(void *vp) {
+  return ((Metadata *)vp)->ptr->num_columns();
+}
+
+int parquet_metadata_num_row_groups(void *vp) {
+  return ((Metadata *)vp)->ptr->num_row_groups();
+}
+
+int64_t parquet_row_group_num_rows(void *vp, int rg) {
+  return ((Metadata *)vp)->ptr->RowGroup(rg)->num_rows();
+}
+
+int64_t parquet_row_group_total_byte_size(void *vp, int rg) {
+  return ((Metadata *)vp)->ptr->RowGroup(rg)->total_byte_size();
+}
+
+int parquet_row_group_num_columns(void *vp, int rg) {
+  return ((Metadata *)vp)->ptr->RowGroup(rg)->num_columns();
+}
+
+char *parquet_column_path(void *vp, int rg, int col) {
+  auto column = ((Metadata *)vp)->ptr->RowGroup(rg)->ColumnChunk(col);
+  return strdup(column->path_in_schema()->ToDotString().c_str());
+}
+
+int64_t parquet_column_num_values(void *vp, int rg, int col) {
+  return ((Metadata *)vp)->ptr->RowGroup(rg)->ColumnChunk(col)->num_values();
+}
+
+stats_t parquet_column_stats(void *vp, int rg, int col) {
+  stats_t out = {};
+  out.kind = STATS_NONE;
+
+  auto column = ((Metadata *)vp)->ptr->RowGroup(rg)->ColumnChunk(col);
+  auto stats = column->statistics();
+  if (!column->is_stats_set() || (stats == nullptr)) {
+    return out;
+  }
+
+  out.has_stats = 1;
+  out.null_count = stats->null_count();
+  out.distinct_count = stats->distinct_count();
+  if (!stats->HasMinMax()) {
+    return out;
+  }
+
+  switch (stats->physical_type()) {
+  case parquet::Type::BOOLEAN: {
+    auto typed = std::static_pointer_cast<parquet::BoolStatistics>(stats);
+    out.kind = STATS_BOOL;
+    out.min_i = typed->min();
+    out.max_i = typed->max();
+    break;
+  }
+  case parquet::Type::INT32: {
+    auto typed = std::static_pointer_cast<parquet::Int32Statistics>(stats);
+    out.kind = STATS_INT;
+    out.min_i = typed->min();
+    out.max_i = typed->max();
+    break;
+  }
+  case parquet::Type::INT64: {
+    auto typed = std::static_pointer_cast<parquet::Int64Statistics>(stats);
+    out.kind = STATS_INT;
+    out.min_i = typed->min();
+    out.max_i = typed->max();
+    break;
+  }
+  case parquet::Type::FLOAT: {
+    auto typed = std::static_pointer_cast<parquet::FloatStatistics>(stats);
+    out.kind = STATS_FLOAT;
+    out.min_f = typed->min();
+    out.max_f = typed->max();
+    break;
+  }
+  case parquet::Type::DOUBLE: {
+    auto typed = std::static_pointer_cast<parquet::DoubleStatistics>(stats);
+    out.kind = STATS_FLOAT;
+    out.min_f = typed->min();
+    out.max_f = typed->max();
+    break;
+  }
+  case parquet::Type::BYTE_ARRAY: {
+    auto typed = std::static_pointer_cast<parquet::ByteArrayStatistics>(stats);
+    out.kind = STATS_STRING;
+    out.min_s = byte_array_string(typed->min());
+    out.max_s = byte_array_string(typed->max());
+    break;
+  }
+  default:
+    // Other physical types (e.g. INT96) have no min/max
+    break;
+  }
+
+  return out;
+}
+
+void parquet_metadata_free(void *vp) {
+  if (vp == nullptr) {
+    return;
+  }
+
+  delete (Metadata *)vp;
+}
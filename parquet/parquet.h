@@ -0,0 +1,70 @@
+#ifndef CARROW_PARQUET_H
+#define CARROW_PARQUET_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+#include <stddef.h>
+#include <stdint.h>
+
+typedef struct {
+  const char *err;
+  void *ptr;
+  int64_t i;
+} result_t;
+
+// Kind of statistics min/max values
+#define STATS_NONE -1
+#define STATS_BOOL 0
+#define STATS_INT 1
+#define STATS_FLOAT 2
+#define STATS_STRING 3
+
+typedef struct {
+  int has_stats;
+  int64_t null_count;
+  int64_t distinct_count;
+  int kind;
+  int64_t min_i;
+  int64_t max_i;
+  double min_f;
+  double max_f;
+  char *min_s; // Must be freed
+  char *max_s; // Must be freed
+} stats_t;
+
+#define COMPRESSION_UNCOMPRESSED 0
+#define COMPRESSION_SNAPPY 1
+#define COMPRESSION_GZIP 2
+#define COMPRESSION_BROTLI 3
+#define COMPRESSION_ZSTD 4
+#define COMPRESSION_LZ4 5
+
+// path is used if not null, otherwise the Go io.ReaderAt registered under id
+result_t parquet_read_table(const char *path, long long id, int64_t size,
+                            char **columns, size_t ncols, int *row_groups,
+                            size_t nrow_groups, int use_threads);
+
+// Writes to the Go io.Writer registered under id
+result_t parquet_write_table(long long id, void *tp, int compression,
+                             int64_t row_group_size, int dictionary,
+                             int statistics);
+
+result_t parquet_metadata_open(const char *path);
+int64_t parquet_metadata_num_rows(void *vp);
+int parquet_metadata_num_columns(void *vp);
+int parquet_metadata_num_row_groups(void *vp);
+int64_t parquet_row_group_num_rows(void *vp, int rg);
+int64_t parquet_row_group_total_byte_size(void *vp, int rg);
+int parquet_row_group_num_columns(void *vp, int rg);
+char *parquet_column_path(void *vp, int rg, int col);
+int64_t parquet_column_num_values(void *vp, int rg, int col);
+stats_t parquet_column_stats(void *vp, int rg, int col);
+void parquet_metadata_free(void *vp);
+
+#ifdef __cplusplus
+}
+#endif // extern "C"
+
+#endif // CARROW_PARQUET_H
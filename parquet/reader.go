@@ -0,0 +1,78 @@
+// Package parquet reads and writes Parquet files
+package parquet
+
+import (
+	"io"
+	"unsafe"
+
+	"github.com/353solutions/carrow"
+)
+
+/*
+#cgo pkg-config: arrow parquet
+
+#include "parquet.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// Options are options for ReadTable
+type Options struct {
+	Columns    []string // Columns to read, all if empty
+	RowGroups  []int    // Row groups to read, all if empty
+	UseThreads bool     // Decode columns in parallel
+}
+
+// ReadTable reads a table from parquet file at path
+func ReadTable(path string, opts Options) (*carrow.Table, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	return readTable(cPath, 0, 0, opts)
+}
+
+// ReadTableFrom reads a table from parquet data in r, size is the data size
+// r.ReadAt may be called concurrently when opts.UseThreads is set
+func ReadTableFrom(r io.ReaderAt, size int64, opts Options) (*carrow.Table, error) {
+	id := reg.alloc(r)
+	defer reg.release(id)
+
+	return readTable(nil, id, size, opts)
+}
+
+func readTable(cPath *C.char, id int64, size int64, opts Options) (*carrow.Table, error) {
+	columns := make([]*C.char, 0, len(opts.Columns)+1)
+	for _, name := range opts.Columns {
+		columns = append(columns, C.CString(name))
+	}
+	defer func() {
+		for _, cp := range columns {
+			C.free(unsafe.Pointer(cp))
+		}
+	}()
+
+	rowGroups := make([]C.int, 0, len(opts.RowGroups)+1)
+	for _, rg := range opts.RowGroups {
+		rowGroups = append(rowGroups, C.int(rg))
+	}
+
+	useThreads := C.int(0)
+	if opts.UseThreads {
+		useThreads = 1
+	}
+
+	// Avoid &x[0] on empty slices
+	cColumns := append(columns, nil)
+	cRowGroups := append(rowGroups, 0)
+	r := C.parquet_read_table(
+		cPath, C.longlong(id), C.int64_t(size),
+		&cColumns[0], C.size_t(len(opts.Columns)),
+		&cRowGroups[0], C.size_t(len(opts.RowGroups)),
+		useThreads,
+	)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return carrow.NewTableFromPtr(r.ptr), nil
+}
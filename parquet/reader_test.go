@@ -0,0 +1,100 @@
+package parquet
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/353solutions/carrow"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTable(require *require.Assertions, size int) *carrow.Table {
+	ib := carrow.NewInteger64ArrayBuilder()
+	sb := carrow.NewStringArrayBuilder()
+	for i := 0; i < size; i++ {
+		require.NoError(ib.Append(int64(i)), "append int")
+		require.NoError(sb.Append(string(rune('a'+i%26))), "append string")
+	}
+	ints, err := ib.Finish()
+	require.NoError(err, "finish ints")
+	strs, err := sb.Finish()
+	require.NoError(err, "finish strings")
+
+	intField, err := carrow.NewField("i", carrow.Integer64Type)
+	require.NoError(err, "int field")
+	strField, err := carrow.NewField("s", carrow.StringType)
+	require.NoError(err, "string field")
+	schema, err := carrow.NewSchema([]*carrow.Field{intField, strField})
+	require.NoError(err, "schema")
+
+	table, err := carrow.NewTableFromArrays(schema, []*carrow.Array{ints, strs})
+	require.NoError(err, "table")
+	return table
+}
+
+func TestReadErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := ReadTable("no-such-file.parquet", Options{})
+	require.Error(err, "missing file")
+
+	data := []byte("this is not a parquet file")
+	_, err = ReadTableFrom(bytes.NewReader(data), int64(len(data)), Options{})
+	require.Error(err, "bad data")
+
+	_, err = Metadata("no-such-file.parquet")
+	require.Error(err, "missing file metadata")
+}
+
+func TestReadOptions(t *testing.T) {
+	require := require.New(t)
+	dir, err := ioutil.TempDir("", "carrow-parquet")
+	require.NoError(err, "temp dir")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "table.parquet")
+
+	file, err := os.Create(path)
+	require.NoError(err, "create")
+	props := DefaultWriterProperties()
+	props.RowGroupSize = 40
+	err = WriteTable(file, buildTable(require, 100), props)
+	require.NoError(err, "write")
+	require.NoError(file.Close(), "close")
+
+	out, err := ReadTable(path, Options{Columns: []string{"s"}, RowGroups: []int{2}, UseThreads: true})
+	require.NoError(err, "read")
+	require.Equal(1, out.NumCols(), "columns")
+	require.Equal(20, out.NumRows(), "rows")
+
+	file, err = os.Open(path)
+	require.NoError(err, "open")
+	defer file.Close()
+	info, err := file.Stat()
+	require.NoError(err, "stat")
+	out, err = ReadTableFrom(file, info.Size(), Options{RowGroups: []int{0}})
+	require.NoError(err, "read from")
+	require.Equal(2, out.NumCols(), "read from columns")
+	require.Equal(40, out.NumRows(), "read from rows")
+
+	_, err = ReadTable(path, Options{Columns: []string{"no-such-column"}})
+	require.Error(err, "unknown column")
+
+	_, err = ReadTable(path, Options{RowGroups: []int{3}})
+	require.Error(err, "bad row group")
+
+	meta, err := Metadata(path)
+	require.NoError(err, "metadata")
+	require.Equal(int64(100), meta.NumRows, "metadata rows")
+	require.Equal(2, meta.NumColumns, "metadata columns")
+	require.Equal(3, meta.NumRowGroups(), "row groups")
+
+	col := meta.RowGroups[1].Columns[0]
+	require.Equal("i", col.Path, "column path")
+	require.NotNil(col.Statistics, "statistics")
+	require.True(col.Statistics.HasMinMax, "has min max")
+	require.Equal(int64(40), col.Statistics.Min, "min")
+	require.Equal(int64(79), col.Statistics.Max, "max")
+}
@@ -0,0 +1,58 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	"github.com/353solutions/carrow/internal/bridge"
+)
+
+/*
+#cgo pkg-config: arrow parquet
+
+#include "parquet.h"
+#include <stdlib.h>
+*/
+import "C"
+
+var (
+	reg = bridge.NewRegistry()
+)
+
+// errFromResult returns the error in r, see bridge.Error
+func errFromResult(r C.result_t) error {
+	return bridge.Error(unsafe.Pointer(r.err))
+}
+
+//export parquet_read_at
+func parquet_read_at(id C.longlong, data unsafe.Pointer, size C.longlong, offset C.longlong, cErr **C.char) C.longlong {
+	// Fill data with size bytes, return less only at end of file
+	r, ok := reg.Get(int64(id)).(io.ReaderAt)
+	if !ok {
+		*cErr = C.CString(fmt.Sprintf("%d: unknown reader id", id))
+		return 0
+	}
+
+	buf := bridge.Bytes(data, int64(size))
+	n, err := r.ReadAt(buf, int64(offset))
+	if err != nil && err != io.EOF {
+		*cErr = C.CString(err.Error())
+	}
+
+	return C.longlong(n)
+}
+
+//export parquet_write
+func parquet_write(id C.longlong, data unsafe.Pointer, size C.longlong) *C.char {
+	w, ok := reg.Get(int64(id)).(io.Writer)
+	if !ok {
+		return C.CString(fmt.Sprintf("%d: unknown writer id", id))
+	}
+
+	if _, err := w.Write(bridge.Bytes(data, int64(size))); err != nil {
+		return C.CString(err.Error())
+	}
+
+	return nil
+}
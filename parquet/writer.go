@@ -0,0 +1,86 @@
+package parquet
+
+import (
+	"io"
+
+	"github.com/353solutions/carrow"
+)
+
+/*
+#cgo pkg-config: arrow parquet
+
+#include "parquet.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// Compression is column compression codec
+type Compression int
+
+// Supported compressions
+const (
+	Uncompressed Compression = C.COMPRESSION_UNCOMPRESSED
+	Snappy       Compression = C.COMPRESSION_SNAPPY
+	Gzip         Compression = C.COMPRESSION_GZIP
+	Brotli       Compression = C.COMPRESSION_BROTLI
+	ZSTD         Compression = C.COMPRESSION_ZSTD
+	LZ4          Compression = C.COMPRESSION_LZ4
+)
+
+func (c Compression) String() string {
+	switch c {
+	case Uncompressed:
+		return "uncompressed"
+	case Snappy:
+		return "snappy"
+	case Gzip:
+		return "gzip"
+	case Brotli:
+		return "brotli"
+	case ZSTD:
+		return "zstd"
+	case LZ4:
+		return "lz4"
+	}
+
+	return "<unknown>"
+}
+
+// WriterProperties are options for WriteTable, start from
+// DefaultWriterProperties
+type WriterProperties struct {
+	Compression  Compression // Default to Uncompressed
+	RowGroupSize int         // Maximal rows in a row group, 0 for parquet default
+	Dictionary   bool        // Use dictionary encoding
+	Statistics   bool        // Write column statistics
+}
+
+// DefaultWriterProperties returns the parquet default writer properties
+// (dictionary encoding and statistics enabled)
+func DefaultWriterProperties() WriterProperties {
+	return WriterProperties{
+		Dictionary: true,
+		Statistics: true,
+	}
+}
+
+// WriteTable writes table to w in parquet format
+func WriteTable(w io.Writer, table *carrow.Table, props WriterProperties) error {
+	id := reg.alloc(w)
+	defer reg.release(id)
+
+	dictionary, statistics := C.int(0), C.int(0)
+	if props.Dictionary {
+		dictionary = 1
+	}
+	if props.Statistics {
+		statistics = 1
+	}
+
+	r := C.parquet_write_table(
+		C.longlong(id), table.Ptr(),
+		C.int(props.Compression), C.int64_t(props.RowGroupSize),
+		dictionary, statistics,
+	)
+	return errFromResult(r)
+}
@@ -0,0 +1,71 @@
+package parquet
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTable(t *testing.T) {
+	require := require.New(t)
+	table := buildTable(require, 100)
+
+	for _, compression := range []Compression{Uncompressed, Snappy, Gzip} {
+		props := DefaultWriterProperties()
+		props.Compression = compression
+		var buf bytes.Buffer
+		err := WriteTable(&buf, table, props)
+		require.NoErrorf(err, "write %s", compression)
+
+		data := buf.Bytes()
+		out, err := ReadTableFrom(bytes.NewReader(data), int64(len(data)), Options{})
+		require.NoErrorf(err, "read %s", compression)
+		ok, err := out.Equal(table)
+		require.NoError(err, "equal")
+		require.Truef(ok, "round trip %s", compression)
+	}
+}
+
+func TestWriteProperties(t *testing.T) {
+	require := require.New(t)
+	dir, err := ioutil.TempDir("", "carrow-parquet")
+	require.NoError(err, "temp dir")
+	defer os.RemoveAll(dir)
+
+	noStats := DefaultWriterProperties()
+	noStats.Statistics = false
+	noDict := DefaultWriterProperties()
+	noDict.Dictionary = false
+
+	testCases := []struct {
+		name  string
+		props WriterProperties
+		stats bool
+	}{
+		{"default", DefaultWriterProperties(), true},
+		{"no statistics", noStats, false},
+		{"no dictionary", noDict, true},
+		{"zero value", WriterProperties{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			path := filepath.Join(dir, tc.name+".parquet")
+			file, err := os.Create(path)
+			require.NoError(err, "create")
+			err = WriteTable(file, buildTable(require, 10), tc.props)
+			require.NoError(err, "write")
+			require.NoError(file.Close(), "close")
+
+			meta, err := Metadata(path)
+			require.NoError(err, "metadata")
+			stats := meta.RowGroups[0].Columns[0].Statistics
+			require.Equal(tc.stats, stats != nil, "statistics")
+		})
+	}
+}
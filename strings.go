@@ -0,0 +1,117 @@
+package carrow
+
+import (
+	"unsafe"
+)
+
+/*
+#include "carrow.h"
+#include <stdlib.h>
+*/
+import "C"
+
+// String functions work on StringType arrays, null values stay null
+
+func (a *Array) strTransform(op C.int, chars string) (*Array, error) {
+	cChars := C.CString(chars)
+	defer C.free(unsafe.Pointer(cChars))
+
+	r := C.compute_str_transform(a.ptr, op, cChars)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// Upper returns a new array with values of a in upper case (ASCII only)
+func (a *Array) Upper() (*Array, error) {
+	return a.strTransform(C.STR_UPPER, "")
+}
+
+// Lower returns a new array with values of a in lower case (ASCII only)
+func (a *Array) Lower() (*Array, error) {
+	return a.strTransform(C.STR_LOWER, "")
+}
+
+// Trim returns a new array with leading and trailing chars removed from values
+// of a. If chars is empty, whitespace is removed
+func (a *Array) Trim(chars string) (*Array, error) {
+	return a.strTransform(C.STR_TRIM, chars)
+}
+
+// StringLength returns an Integer64Type array with the number of characters
+// (UTF-8 code points) in every value of a
+func (a *Array) StringLength() (*Array, error) {
+	r := C.compute_str_length(a.ptr)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+func (a *Array) strMatch(op C.int, pattern string) (*Array, error) {
+	cPattern := C.CString(pattern)
+	defer C.free(unsafe.Pointer(cPattern))
+
+	r := C.compute_str_match(a.ptr, op, cPattern)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// StartsWith returns a BoolType array which is true where values of a start
+// with prefix
+func (a *Array) StartsWith(prefix string) (*Array, error) {
+	return a.strMatch(C.STR_STARTS_WITH, prefix)
+}
+
+// EndsWith returns a BoolType array which is true where values of a end with
+// suffix
+func (a *Array) EndsWith(suffix string) (*Array, error) {
+	return a.strMatch(C.STR_ENDS_WITH, suffix)
+}
+
+// MatchSubstring returns a BoolType array which is true where values of a
+// contain substr
+func (a *Array) MatchSubstring(substr string) (*Array, error) {
+	return a.strMatch(C.STR_CONTAINS, substr)
+}
+
+// Contains is MatchSubstring
+func (a *Array) Contains(substr string) (*Array, error) {
+	return a.MatchSubstring(substr)
+}
+
+// Split returns a ListType array with values of a split around sep, use
+// ListAt to get the parts of a value
+// At most maxSplits splits are done, -1 means no limit
+func (a *Array) Split(sep string, maxSplits int) (*Array, error) {
+	cSep := C.CString(sep)
+	defer C.free(unsafe.Pointer(cSep))
+
+	r := C.compute_str_split(a.ptr, cSep, C.int64_t(maxSplits))
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
+
+// Strptime returns a TimestampType array with values of a parsed with format
+// (see strptime(3)). Times are in UTC, the whole value must match format
+// %z offsets are applied, %Z (zone name) is not supported
+func (a *Array) Strptime(format string) (*Array, error) {
+	cFormat := C.CString(format)
+	defer C.free(unsafe.Pointer(cFormat))
+
+	r := C.compute_strptime(a.ptr, cFormat)
+	if err := errFromResult(r); err != nil {
+		return nil, err
+	}
+
+	return &Array{r.ptr}, nil
+}
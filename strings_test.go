@@ -0,0 +1,106 @@
+package carrow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringTransform(t *testing.T) {
+	require := require.New(t)
+	arr := buildStringArray(require, "  Hello ", "wORLD", "")
+
+	out, err := arr.Upper()
+	require.NoError(err, "upper")
+	ok, err := out.Equal(buildStringArray(require, "  HELLO ", "WORLD", ""))
+	require.NoError(err, "equal")
+	require.True(ok, "upper values")
+
+	out, err = arr.Lower()
+	require.NoError(err, "lower")
+	ok, err = out.Equal(buildStringArray(require, "  hello ", "world", ""))
+	require.NoError(err, "equal")
+	require.True(ok, "lower values")
+
+	out, err = arr.Trim("")
+	require.NoError(err, "trim")
+	ok, err = out.Equal(buildStringArray(require, "Hello", "wORLD", ""))
+	require.NoError(err, "equal")
+	require.True(ok, "trim values")
+
+	out, err = buildStringArray(require, "héllo", "").StringLength()
+	require.NoError(err, "length")
+	ok, err = out.Equal(buildIntArray(require, 5, 0))
+	require.NoError(err, "equal")
+	require.True(ok, "length values")
+
+	_, err = buildIntArray(require, 1).Upper()
+	require.Error(err, "upper on int")
+}
+
+func TestStringMatch(t *testing.T) {
+	require := require.New(t)
+	arr := buildStringArray(require, "carrow", "arrow", "car")
+
+	mask, err := arr.StartsWith("car")
+	require.NoError(err, "starts with")
+	ok, err := mask.Equal(buildBoolArray(require, true, false, true))
+	require.NoError(err, "equal")
+	require.True(ok, "starts with values")
+
+	mask, err = arr.Contains("rro")
+	require.NoError(err, "contains")
+	ok, err = mask.Equal(buildBoolArray(require, true, true, false))
+	require.NoError(err, "equal")
+	require.True(ok, "contains values")
+}
+
+func TestSplit(t *testing.T) {
+	require := require.New(t)
+	arr := buildStringArray(require, "a,b,c", "d")
+
+	out, err := arr.Split(",", -1)
+	require.NoError(err, "split")
+	require.Equal(ListType, out.DType(), "split dtype")
+	parts, err := out.ListAt(0)
+	require.NoError(err, "ListAt(0)")
+	ok, err := parts.Equal(buildStringArray(require, "a", "b", "c"))
+	require.NoError(err, "equal")
+	require.True(ok, "split values")
+
+	out, err = arr.Split(",", 1)
+	require.NoError(err, "split max")
+	parts, err = out.ListAt(0)
+	require.NoError(err, "ListAt(0)")
+	ok, err = parts.Equal(buildStringArray(require, "a", "b,c"))
+	require.NoError(err, "equal")
+	require.True(ok, "split max values")
+}
+
+func TestStrptime(t *testing.T) {
+	require := require.New(t)
+	arr := buildStringArray(require, "2020-03-15 12:30:00")
+
+	out, err := arr.Strptime("%Y-%m-%d %H:%M:%S")
+	require.NoError(err, "strptime")
+	require.Equal(TimestampType, out.DType(), "dtype")
+	ts, err := out.TimeAt(0)
+	require.NoError(err, "TimeAt(0)")
+	expected := time.Date(2020, 3, 15, 12, 30, 0, 0, time.UTC)
+	require.True(expected.Equal(ts), "time value")
+
+	_, err = arr.Strptime("%Y")
+	require.Error(err, "bad format")
+
+	arr = buildStringArray(require, "2020-03-15 12:30:00 +0200")
+	out, err = arr.Strptime("%Y-%m-%d %H:%M:%S %z")
+	require.NoError(err, "strptime offset")
+	ts, err = out.TimeAt(0)
+	require.NoError(err, "TimeAt(0)")
+	expected = time.Date(2020, 3, 15, 10, 30, 0, 0, time.UTC)
+	require.True(expected.Equal(ts), "offset applied")
+
+	_, err = arr.Strptime("%Y-%m-%d %H:%M:%S %Z")
+	require.Error(err, "zone name")
+}